@@ -0,0 +1,304 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/celestiaorg/test-infra/cmd/internal/manifest"
+)
+
+// defaultPath follows the naming scheme of compositions/README.md
+const defaultPath = "{{if .SquareSize}}{{.SquareSize}}-square-size/{{end}}" +
+	"{{.Topology}}/{{.Bandwidth}}-{{.Latency}}{{if .Getter}}-{{.Getter}}{{end}}"
+
+// Variant holds the names of the values of a composition in every dimension of
+// the sweep, which the path template of the spec is executed with
+type Variant struct {
+	// Topology is the counts of the name-groups, e.g. "40-40-20-100"
+	Topology string
+	// Bandwidth is e.g. "320-100mib" for 320Mib by default and 100Mib for a group
+	Bandwidth string
+	// Latency is e.g. "200ms"
+	Latency string
+	// SquareSize is empty when the spec has no square sizes
+	SquareSize string
+	// Getter is empty when the spec has no getters
+	Getter string
+}
+
+type group struct {
+	Group
+	count  int
+	params map[string]string
+}
+
+type composition struct {
+	spec   *Spec
+	path   string
+	name   string
+	total  int
+	params map[string]string
+	groups []group
+	build  buildConfig
+}
+
+type buildConfig struct {
+	baseImage  string
+	goVersion  string
+	buildCache bool
+}
+
+// generate returns a composition for every combination of the dimensions of the spec
+func generate(s *Spec, m *manifest.Manifest) ([]*composition, error) {
+	path := s.Path
+	if path == "" {
+		path = defaultPath
+	}
+	tmpl, err := template.New("path").Option("missingkey=error").Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path template: %w", err)
+	}
+
+	b, ok := m.Builders[s.Builder]
+	if !ok {
+		return nil, fmt.Errorf("builder %q is not configured in manifest.toml", s.Builder)
+	}
+	build := buildConfig{b.BuildBaseImage, b.GoVersion, b.EnableGoBuildCache}
+
+	// a dimension without values doesn't multiply the compositions
+	squareSizes := s.SquareSizes
+	if len(squareSizes) == 0 {
+		squareSizes = []SquareSize{{}}
+	}
+	getters := s.Getters
+	if len(getters) == 0 {
+		getters = []Getter{{}}
+	}
+
+	var comps []*composition
+	for _, sq := range squareSizes {
+		for _, g := range getters {
+			for _, t := range s.Topologies {
+				for _, bw := range s.Bandwidths {
+					for _, lat := range s.Latencies {
+						c, err := s.compose(tmpl, build, t, bw, lat, sq, g)
+						if err != nil {
+							return nil, err
+						}
+						comps = append(comps, c)
+					}
+				}
+			}
+		}
+	}
+	return comps, nil
+}
+
+// compose builds a single composition of the sweep. The global params are layered
+// in the order of the dimensions, so a square size overrides the params of the spec
+// and the topology overrides all of them
+func (s *Spec) compose(
+	tmpl *template.Template,
+	build buildConfig,
+	t Topology,
+	bw map[string]string,
+	lat int,
+	sq SquareSize,
+	g Getter,
+) (*composition, error) {
+	v := Variant{
+		Topology:  s.topologyName(t),
+		Bandwidth: s.bandwidthName(bw),
+		Latency:   fmt.Sprintf("%dms", lat),
+		Getter:    g.Name,
+	}
+	if sq.Size > 0 {
+		v.SquareSize = strconv.Itoa(sq.Size)
+	}
+
+	var path strings.Builder
+	err := tmpl.Execute(&path, v)
+	if err != nil {
+		return nil, fmt.Errorf("executing path template: %w", err)
+	}
+
+	c := &composition{
+		spec:   s,
+		path:   path.String() + ".toml",
+		name:   s.Case + "-" + strings.ReplaceAll(path.String(), "/", "-"),
+		params: make(map[string]string),
+		build:  build,
+	}
+
+	for _, params := range []map[string]string{s.Params, sq.Params, g.Params, t.Params} {
+		for k, v := range params {
+			c.params[k] = v
+		}
+	}
+	if g.Getter != "" {
+		c.params["getter"] = g.Getter
+	}
+
+	for _, gr := range s.Groups {
+		count := t.Counts[gr.ID]
+		if gr.CountParam != "" {
+			c.params[gr.CountParam] = strconv.Itoa(count)
+		}
+		// testground doesn't accept empty groups, the count param tells the others it's absent
+		if count == 0 {
+			continue
+		}
+
+		params := map[string]string{
+			"role":      gr.Role,
+			"latency":   strconv.Itoa(lat),
+			"bandwidth": bw["default"],
+		}
+		if b, ok := bw[gr.ID]; ok {
+			params["bandwidth"] = b
+		}
+		for k, v := range gr.Params {
+			params[k] = v
+		}
+
+		c.groups = append(c.groups, group{Group: gr, count: count, params: params})
+		c.total += count
+	}
+	return c, nil
+}
+
+// validate checks the composition against the test case declared in the manifest
+func (c *composition) validate(m *manifest.Manifest) []string {
+	tc, ok := m.TestCase(c.spec.Case)
+	if !ok {
+		return []string{fmt.Sprintf("test case %q is not declared in manifest.toml", c.spec.Case)}
+	}
+
+	var problems []string
+	if c.total < tc.Instances.Min || c.total > tc.Instances.Max {
+		problems = append(problems, fmt.Sprintf(
+			"%d instances, test case %q accepts between %d and %d",
+			c.total, tc.Name, tc.Instances.Min, tc.Instances.Max,
+		))
+	}
+	for _, param := range sortedKeys(c.params) {
+		if _, ok := tc.Params[param]; !ok {
+			problems = append(problems, fmt.Sprintf("unknown param %q in global test_params of test case %q", param, tc.Name))
+		}
+	}
+	for _, g := range c.groups {
+		for _, param := range sortedKeys(g.params) {
+			if _, ok := tc.Params[param]; !ok {
+				problems = append(problems, fmt.Sprintf(
+					"unknown param %q in test_params of group %q of test case %q", param, g.ID, tc.Name,
+				))
+			}
+		}
+	}
+	return problems
+}
+
+// render formats the composition the way the compositions of the repository are
+func (c *composition) render() []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# generated by cmd/gen-compositions, edit the sweep spec instead\n\n")
+	fmt.Fprintf(&b, "[metadata]\n")
+	fmt.Fprintf(&b, "  name = %s\n", strconv.Quote(c.name))
+	fmt.Fprintf(&b, "  author = %s\n", strconv.Quote(c.spec.Author))
+	fmt.Fprintf(&b, "\n[global]\n")
+	fmt.Fprintf(&b, "  plan = \"celestia\"\n")
+	fmt.Fprintf(&b, "  case = %s\n", strconv.Quote(c.spec.Case))
+	fmt.Fprintf(&b, "  total_instances = %d\n", c.total)
+	fmt.Fprintf(&b, "  builder = %s\n", strconv.Quote(c.spec.Builder))
+	fmt.Fprintf(&b, "  runner = %s\n", strconv.Quote(c.spec.Runner))
+	fmt.Fprintf(&b, "  disable_metrics = false\n")
+	fmt.Fprintf(&b, "  [global.run]\n")
+	fmt.Fprintf(&b, "    artifact = \"\"\n")
+	fmt.Fprintf(&b, "    [global.run.test_params]\n")
+	writeParams(&b, c.params)
+
+	for _, g := range c.groups {
+		fmt.Fprintf(&b, "\n[[groups]]\n")
+		fmt.Fprintf(&b, "  id = %s\n", strconv.Quote(g.ID))
+		fmt.Fprintf(&b, "  builder = %s\n", strconv.Quote(c.spec.Builder))
+		if g.Memory != "" || g.CPU != "" {
+			fmt.Fprintf(&b, "  [groups.resources]\n")
+			if g.Memory != "" {
+				fmt.Fprintf(&b, "    memory = %s\n", strconv.Quote(g.Memory))
+			}
+			if g.CPU != "" {
+				fmt.Fprintf(&b, "    cpu = %s\n", strconv.Quote(g.CPU))
+			}
+		}
+		fmt.Fprintf(&b, "  [groups.instances]\n")
+		fmt.Fprintf(&b, "    count = %d\n", g.count)
+		fmt.Fprintf(&b, "    percentage = 0.0\n")
+		fmt.Fprintf(&b, "  [groups.build_config]\n")
+		fmt.Fprintf(&b, "    build_base_image = %s\n", strconv.Quote(c.build.baseImage))
+		fmt.Fprintf(&b, "    enable_go_build_cache = %t\n", c.build.buildCache)
+		fmt.Fprintf(&b, "    enabled = true\n")
+		fmt.Fprintf(&b, "    go_version = %s\n", strconv.Quote(c.build.goVersion))
+		fmt.Fprintf(&b, "  [groups.build]\n")
+		fmt.Fprintf(&b, "  [groups.run]\n")
+		fmt.Fprintf(&b, "    artifact = \"\"\n")
+		fmt.Fprintf(&b, "    [groups.run.test_params]\n")
+		writeParams(&b, g.params)
+	}
+	return []byte(b.String())
+}
+
+func writeParams(b *strings.Builder, params map[string]string) {
+	for _, k := range sortedKeys(params) {
+		fmt.Fprintf(b, "      %s = %s\n", k, strconv.Quote(params[k]))
+	}
+}
+
+// topologyName joins the counts of the name-groups, e.g. "40-40-20-100"
+func (s *Spec) topologyName(t Topology) string {
+	counts := make([]string, len(s.NameGroups))
+	for i, id := range s.NameGroups {
+		counts[i] = strconv.Itoa(t.Counts[id])
+	}
+	return strings.Join(counts, "-")
+}
+
+var bandwidthRe = regexp.MustCompile(`^(\d+)(\D*)$`)
+
+// bandwidthName joins the distinct bandwidths, the default one first, and
+// puts their unit once at the end when they share it, e.g. "320-100mib"
+func (s *Spec) bandwidthName(bw map[string]string) string {
+	values := []string{bw["default"]}
+	seen := map[string]bool{bw["default"]: true}
+	for _, g := range s.Groups {
+		if b, ok := bw[g.ID]; ok && !seen[b] {
+			values = append(values, b)
+			seen[b] = true
+		}
+	}
+
+	var (
+		amounts = make([]string, len(values))
+		unit    string
+	)
+	for i, v := range values {
+		m := bandwidthRe.FindStringSubmatch(v)
+		if m == nil || (i > 0 && !strings.EqualFold(m[2], unit)) {
+			return strings.ToLower(strings.Join(values, "-"))
+		}
+		amounts[i], unit = m[1], m[2]
+	}
+	return strings.Join(amounts, "-") + strings.ToLower(unit)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,123 @@
+/*
+gen-compositions generates the compositions of a parameter sweep, e.g. the same
+test case for several amounts of nodes, bandwidths, latencies and square sizes.
+
+A sweep is described by a spec (see Spec and the specs in sweeps/). A composition
+is generated for every combination of its topologies, bandwidths, latencies,
+square sizes and getters and named after the compositions/README.md scheme:
+
+	test-case-id -> participants-amount -> bandwidth-latency-per-participant
+
+Every generated composition is checked against manifest.toml before anything is
+written: the test case has to be declared, every param has to be declared for it
+and the amount of instances has to be within its bounds.
+
+	go run ./cmd/gen-compositions -root . sweeps/*.toml
+
+With -check, nothing is written and the command fails if the compositions on disk
+are not the ones the specs generate.
+*/
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/celestiaorg/test-infra/cmd/internal/manifest"
+)
+
+func main() {
+	root := flag.String("root", ".", "root directory of the repository")
+	check := flag.Bool("check", false, "check the compositions on disk are up to date instead of writing them")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [-root dir] [-check] spec.toml...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	problems, err := run(*root, flag.Args(), *check)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	for _, p := range problems {
+		fmt.Println(p)
+	}
+	if len(problems) > 0 {
+		fmt.Printf("%d problems found\n", len(problems))
+		os.Exit(1)
+	}
+}
+
+func run(root string, specs []string, check bool) ([]string, error) {
+	m, err := manifest.Read(filepath.Join(root, "manifest.toml"))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		problems []string
+		files    = make(map[string][]byte)
+	)
+	for _, path := range specs {
+		s, err := readSpec(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		comps, err := generate(s, m)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		for _, c := range comps {
+			for _, p := range c.validate(m) {
+				problems = append(problems, fmt.Sprintf("%s: %s: %s", path, c.path, p))
+			}
+
+			out := filepath.Join(root, s.Dir, c.path)
+			if _, ok := files[out]; ok {
+				problems = append(problems, fmt.Sprintf("%s: %s is generated more than once", path, out))
+			}
+			files[out] = c.render()
+		}
+	}
+	if len(problems) > 0 {
+		return problems, nil
+	}
+
+	for _, out := range sortedKeys(files) {
+		if check {
+			existing, err := os.ReadFile(out)
+			switch {
+			case os.IsNotExist(err):
+				problems = append(problems, fmt.Sprintf("%s is missing", out))
+			case err != nil:
+				return nil, err
+			case !bytes.Equal(existing, files[out]):
+				problems = append(problems, fmt.Sprintf("%s is out of date", out))
+			}
+			continue
+		}
+
+		err = os.MkdirAll(filepath.Dir(out), 0o755)
+		if err != nil {
+			return nil, err
+		}
+		err = os.WriteFile(out, files[out], 0o644)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Println(out)
+	}
+	return problems, nil
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/celestiaorg/test-infra/cmd/internal/manifest"
+)
+
+// TestRepository fails when the compositions on disk are not the ones the sweeps generate
+func TestRepository(t *testing.T) {
+	root := filepath.Join("..", "..")
+	specs, err := filepath.Glob(filepath.Join(root, "sweeps", "*.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(specs) == 0 {
+		t.Fatal("no sweep spec found")
+	}
+
+	problems, err := run(root, specs, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range problems {
+		t.Error(p)
+	}
+}
+
+const testManifest = `name = "test"
+
+[builders."docker:generic"]
+build_base_image = "golang:1.21"
+go_version = "1.21"
+enable_go_build_cache = true
+`
+
+var testGroups = []Group{
+	{ID: "validators", Role: "validator", CountParam: "validator"},
+	{ID: "bridges", Role: "bridge", CountParam: "bridge", Params: map[string]string{"block-height": "10"}},
+	{ID: "lights", Role: "light"},
+}
+
+// want is the part of a composition checked by TestGenerate
+type want struct {
+	path   string
+	total  int
+	params map[string]string
+	// groups are the params of every group generated, keyed by their id
+	groups map[string]map[string]string
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name string
+		spec Spec
+		want []want
+	}{
+		{
+			name: "default path",
+			spec: Spec{
+				Topologies: []Topology{{Counts: map[string]int{"validators": 2, "bridges": 1, "lights": 4}}},
+				Bandwidths: []map[string]string{{"default": "320Mib"}},
+				Latencies:  []int{0},
+			},
+			want: []want{{
+				path:   "2-1-4/320mib-0ms.toml",
+				total:  7,
+				params: map[string]string{"validator": "2", "bridge": "1"},
+				groups: map[string]map[string]string{
+					"validators": {"role": "validator", "latency": "0", "bandwidth": "320Mib"},
+					"bridges":    {"role": "bridge", "latency": "0", "bandwidth": "320Mib", "block-height": "10"},
+					"lights":     {"role": "light", "latency": "0", "bandwidth": "320Mib"},
+				},
+			}},
+		},
+		{
+			name: "bandwidth shared across groups",
+			spec: Spec{
+				Topologies: []Topology{{Counts: map[string]int{"validators": 1, "bridges": 1, "lights": 1}}},
+				Bandwidths: []map[string]string{{"default": "320Mib", "bridges": "100Mib", "lights": "100Mib"}},
+				Latencies:  []int{100},
+			},
+			want: []want{{
+				path:   "1-1-1/320-100mib-100ms.toml",
+				total:  3,
+				params: map[string]string{"validator": "1", "bridge": "1"},
+				groups: map[string]map[string]string{
+					"validators": {"role": "validator", "latency": "100", "bandwidth": "320Mib"},
+					"bridges":    {"role": "bridge", "latency": "100", "bandwidth": "100Mib", "block-height": "10"},
+					"lights":     {"role": "light", "latency": "100", "bandwidth": "100Mib"},
+				},
+			}},
+		},
+		{
+			name: "empty groups skipped",
+			spec: Spec{
+				Topologies: []Topology{{Counts: map[string]int{"validators": 3, "lights": 2}}},
+				Bandwidths: []map[string]string{{"default": "1Gib", "lights": "100Mib"}},
+				Latencies:  []int{0},
+			},
+			want: []want{{
+				path:   "3-0-2/1gib-100mib-0ms.toml",
+				total:  5,
+				params: map[string]string{"validator": "3", "bridge": "0"},
+				groups: map[string]map[string]string{
+					"validators": {"role": "validator", "latency": "0", "bandwidth": "1Gib"},
+					"lights":     {"role": "light", "latency": "0", "bandwidth": "100Mib"},
+				},
+			}},
+		},
+		{
+			name: "params layered in the order of the dimensions",
+			spec: Spec{
+				NameGroups:  []string{"validators"},
+				Params:      map[string]string{"a": "spec", "b": "spec", "c": "spec", "d": "spec"},
+				Topologies:  []Topology{{Counts: map[string]int{"validators": 1}, Params: map[string]string{"a": "topology"}}},
+				Bandwidths:  []map[string]string{{"default": "320Mib"}},
+				Latencies:   []int{0},
+				SquareSizes: []SquareSize{{Size: 64, Params: map[string]string{"a": "square", "b": "square", "c": "square"}}},
+				Getters:     []Getter{{Name: "ipld", Getter: "ipld", Params: map[string]string{"a": "getter", "b": "getter"}}},
+			},
+			want: []want{{
+				path:  "64-square-size/1/320mib-0ms-ipld.toml",
+				total: 1,
+				params: map[string]string{
+					"a":         "topology",
+					"b":         "getter",
+					"c":         "square",
+					"d":         "spec",
+					"getter":    "ipld",
+					"validator": "1",
+					"bridge":    "0",
+				},
+				groups: map[string]map[string]string{
+					"validators": {"role": "validator", "latency": "0", "bandwidth": "320Mib"},
+				},
+			}},
+		},
+		{
+			name: "every combination",
+			spec: Spec{
+				NameGroups: []string{"validators"},
+				Path:       "{{.Latency}}/{{.Topology}}-{{.Bandwidth}}",
+				Topologies: []Topology{
+					{Counts: map[string]int{"validators": 1}},
+					{Counts: map[string]int{"validators": 2}},
+				},
+				Bandwidths: []map[string]string{{"default": "320Mib"}},
+				Latencies:  []int{0, 100},
+			},
+			want: []want{
+				{
+					path:   "0ms/1-320mib.toml",
+					total:  1,
+					params: map[string]string{"validator": "1", "bridge": "0"},
+					groups: map[string]map[string]string{"validators": {"role": "validator", "latency": "0", "bandwidth": "320Mib"}},
+				},
+				{
+					path:   "100ms/1-320mib.toml",
+					total:  1,
+					params: map[string]string{"validator": "1", "bridge": "0"},
+					groups: map[string]map[string]string{"validators": {"role": "validator", "latency": "100", "bandwidth": "320Mib"}},
+				},
+				{
+					path:   "0ms/2-320mib.toml",
+					total:  2,
+					params: map[string]string{"validator": "2", "bridge": "0"},
+					groups: map[string]map[string]string{"validators": {"role": "validator", "latency": "0", "bandwidth": "320Mib"}},
+				},
+				{
+					path:   "100ms/2-320mib.toml",
+					total:  2,
+					params: map[string]string{"validator": "2", "bridge": "0"},
+					groups: map[string]map[string]string{"validators": {"role": "validator", "latency": "100", "bandwidth": "320Mib"}},
+				},
+			},
+		},
+	}
+
+	m := &manifest.Manifest{}
+	err := toml.Unmarshal([]byte(testManifest), m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.spec
+			s.Case, s.Dir, s.Builder, s.Groups = "case", "compositions/case", "docker:generic", testGroups
+			if len(s.NameGroups) == 0 {
+				s.NameGroups = []string{"validators", "bridges", "lights"}
+			}
+
+			comps, err := generate(&s, m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(comps) != len(tt.want) {
+				t.Fatalf("expected %d compositions, got %d", len(tt.want), len(comps))
+			}
+			for i, c := range comps {
+				err := check(c, tt.want[i])
+				if err != nil {
+					t.Errorf("composition #%d: %s", i, err)
+				}
+			}
+		})
+	}
+}
+
+// check returns an error describing the first difference between the composition and the expected one
+func check(c *composition, w want) error {
+	if c.path != w.path {
+		return fmt.Errorf("expected the path %s, got %s", w.path, c.path)
+	}
+	if c.total != w.total {
+		return fmt.Errorf("expected %d instances, got %d", w.total, c.total)
+	}
+	if !reflect.DeepEqual(c.params, w.params) {
+		return fmt.Errorf("expected the params %v, got %v", w.params, c.params)
+	}
+
+	groups := make(map[string]map[string]string, len(c.groups))
+	for _, g := range c.groups {
+		groups[g.ID] = g.params
+	}
+	if !reflect.DeepEqual(groups, w.groups) {
+		return fmt.Errorf("expected the groups %v, got %v", w.groups, groups)
+	}
+	return nil
+}
+
+func TestBandwidthName(t *testing.T) {
+	s := &Spec{Groups: testGroups}
+	tests := []struct {
+		name string
+		bw   map[string]string
+		want string
+	}{
+		{name: "default only", bw: map[string]string{"default": "320Mib"}, want: "320mib"},
+		{name: "same unit", bw: map[string]string{"default": "320Mib", "lights": "100Mib"}, want: "320-100mib"},
+		{name: "unit of another case", bw: map[string]string{"default": "320Mib", "lights": "100MiB"}, want: "320-100mib"},
+		{name: "mixed units", bw: map[string]string{"default": "1Gib", "lights": "100Mib"}, want: "1gib-100mib"},
+		{
+			name: "shared across groups",
+			bw:   map[string]string{"default": "320Mib", "bridges": "100Mib", "lights": "100Mib"},
+			want: "320-100mib",
+		},
+		{name: "same as the default", bw: map[string]string{"default": "320Mib", "bridges": "320Mib"}, want: "320mib"},
+		{
+			name: "in the order of the groups",
+			bw:   map[string]string{"default": "320Mib", "lights": "10Mib", "validators": "1000Mib"},
+			want: "320-1000-10mib",
+		},
+		{name: "without unit", bw: map[string]string{"default": "320", "lights": "100"}, want: "320-100"},
+		{name: "not a number", bw: map[string]string{"default": "Unlimited", "lights": "100Mib"}, want: "unlimited-100mib"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.bandwidthName(tt.bw)
+			if got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
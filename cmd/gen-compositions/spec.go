@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pelletier/go-toml"
+)
+
+// Spec describes a sweep: the compositions of a test case for every combination
+// of its topologies, bandwidths, latencies, square sizes and getters
+type Spec struct {
+	Case    string `toml:"case"`
+	Author  string `toml:"author"`
+	Runner  string `toml:"runner"`
+	Builder string `toml:"builder"`
+	// Dir is where the compositions are written, relative to the root of the repository
+	Dir string `toml:"dir"`
+	// Path is a text/template of the path of a composition within Dir, without
+	// the extension. See Variant for the fields. Defaults to the README naming scheme:
+	// [square-size/]participants-amount/bandwidth-latency[-getter]
+	Path string `toml:"path"`
+	// NameGroups are the groups whose counts name a topology, in order. Defaults to all the groups
+	NameGroups []string `toml:"name-groups"`
+
+	// Params are the global test params shared by all the compositions
+	Params map[string]string `toml:"params"`
+	Groups []Group           `toml:"groups"`
+
+	Topologies  []Topology          `toml:"topologies"`
+	Bandwidths  []map[string]string `toml:"bandwidths"`
+	Latencies   []int               `toml:"latencies"`
+	SquareSizes []SquareSize        `toml:"square-sizes"`
+	Getters     []Getter            `toml:"getters"`
+}
+
+// Group is a node type of the test case
+type Group struct {
+	ID   string `toml:"id"`
+	Role string `toml:"role"`
+	// CountParam is the global param set to the amount of instances of the group, e.g. "validator"
+	CountParam string            `toml:"count-param"`
+	Memory     string            `toml:"memory"`
+	CPU        string            `toml:"cpu"`
+	Params     map[string]string `toml:"params"`
+}
+
+// Topology is the amount of instances of every group, keyed by the group id
+type Topology struct {
+	Counts map[string]int    `toml:"counts"`
+	Params map[string]string `toml:"params"`
+}
+
+// SquareSize is the size of the extended data square the params of the entry produce
+type SquareSize struct {
+	Size   int               `toml:"size"`
+	Params map[string]string `toml:"params"`
+}
+
+// Getter is the way the DA nodes retrieve the shares. Name defaults to the getter
+type Getter struct {
+	Name   string            `toml:"name"`
+	Getter string            `toml:"getter"`
+	Params map[string]string `toml:"params"`
+}
+
+// readSpec parses the sweep spec at path and fills the defaults
+func readSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Spec{}
+	err = toml.Unmarshal(data, s)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	if s.Runner == "" {
+		s.Runner = "cluster:k8s"
+	}
+	if s.Builder == "" {
+		s.Builder = "docker:generic"
+	}
+	if len(s.NameGroups) == 0 {
+		for _, g := range s.Groups {
+			s.NameGroups = append(s.NameGroups, g.ID)
+		}
+	}
+	for i := range s.Getters {
+		if s.Getters[i].Name == "" {
+			s.Getters[i].Name = s.Getters[i].Getter
+		}
+	}
+	return s, s.validate()
+}
+
+// validate checks the spec is consistent with itself, the manifest is checked
+// against the generated compositions
+func (s *Spec) validate() error {
+	if s.Case == "" || s.Dir == "" {
+		return fmt.Errorf("case and dir are required")
+	}
+	if len(s.Groups) == 0 || len(s.Topologies) == 0 {
+		return fmt.Errorf("at least one group and one topology are required")
+	}
+	if len(s.Bandwidths) == 0 || len(s.Latencies) == 0 {
+		return fmt.Errorf("at least one bandwidth and one latency are required")
+	}
+
+	groups := make(map[string]bool, len(s.Groups))
+	for _, g := range s.Groups {
+		if g.ID == "" || g.Role == "" {
+			return fmt.Errorf("every group requires an id and a role")
+		}
+		groups[g.ID] = true
+	}
+	for _, id := range s.NameGroups {
+		if !groups[id] {
+			return fmt.Errorf("unknown group %q in name-groups", id)
+		}
+	}
+	for i, t := range s.Topologies {
+		for id, count := range t.Counts {
+			if !groups[id] {
+				return fmt.Errorf("topology #%d: unknown group %q", i, id)
+			}
+			if count < 0 {
+				return fmt.Errorf("topology #%d: negative count %d of group %q", i, count, id)
+			}
+		}
+	}
+	for i, b := range s.Bandwidths {
+		if b["default"] == "" {
+			return fmt.Errorf("bandwidth #%d: default bandwidth is required", i)
+		}
+		for id := range b {
+			if id != "default" && !groups[id] {
+				return fmt.Errorf("bandwidth #%d: unknown group %q", i, id)
+			}
+		}
+	}
+	for _, g := range s.Getters {
+		if g.Name == "" {
+			return fmt.Errorf("every getter requires a name or a getter")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+Package manifest parses manifest.toml and the compositions running its test cases,
+for the commands which check or generate them
+*/
+package manifest
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pelletier/go-toml"
+)
+
+// Manifest is the part of manifest.toml the commands rely on
+type Manifest struct {
+	Name     string `toml:"name"`
+	Builders map[string]struct {
+		BuildBaseImage     string `toml:"build_base_image"`
+		GoVersion          string `toml:"go_version"`
+		EnableGoBuildCache bool   `toml:"enable_go_build_cache"`
+	} `toml:"builders"`
+	Testcases []TestCase `toml:"testcases"`
+}
+
+// TestCase is a test case declared in the manifest
+type TestCase struct {
+	Name      string `toml:"name"`
+	Instances struct {
+		Min     int `toml:"min"`
+		Max     int `toml:"max"`
+		Default int `toml:"default"`
+	} `toml:"instances"`
+	Params map[string]interface{} `toml:"params"`
+}
+
+// Defaults returns the default value of every param which has one, formatted
+// the way testground passes it to the instances
+func (tc *TestCase) Defaults() map[string]string {
+	defaults := make(map[string]string, len(tc.Params))
+	for name, param := range tc.Params {
+		p, ok := param.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if v, ok := p["default"]; ok {
+			defaults[name] = fmt.Sprint(v)
+		}
+	}
+	return defaults
+}
+
+// Composition is the part of a composition the commands rely on
+type Composition struct {
+	Global struct {
+		Plan string `toml:"plan"`
+		Case string `toml:"case"`
+		Run  struct {
+			TestParams map[string]interface{} `toml:"test_params"`
+		} `toml:"run"`
+	} `toml:"global"`
+	Groups []struct {
+		ID        string `toml:"id"`
+		Instances struct {
+			Count int `toml:"count"`
+		} `toml:"instances"`
+		Run struct {
+			TestParams map[string]interface{} `toml:"test_params"`
+		} `toml:"run"`
+	} `toml:"groups"`
+}
+
+// Read parses the manifest at path
+func Read(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{}
+	err = toml.Unmarshal(data, m)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// TestCase returns the declared test case with the given name
+func (m *Manifest) TestCase(name string) (*TestCase, bool) {
+	for i := range m.Testcases {
+		if m.Testcases[i].Name == name {
+			return &m.Testcases[i], true
+		}
+	}
+	return nil, false
+}
+
+// ReadComposition parses the composition at path
+func ReadComposition(path string) (*Composition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Composition{}
+	err = toml.Unmarshal(data, c)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
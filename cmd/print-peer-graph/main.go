@@ -0,0 +1,56 @@
+/*
+print-peer-graph prints the graph of testkit/graphkit of a single config as JSON,
+e.g. to look at the peers the validators of a composition get:
+
+	go run ./cmd/print-peer-graph -kind small-world -nodes 12 -degree 4 -seed 7
+
+The guarantees of the graphs are checked over many configs by the tests of graphkit.
+*/
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/celestiaorg/test-infra/testkit/graphkit"
+)
+
+func main() {
+	nodes := flag.Int("nodes", 48, "amount of nodes")
+	degree := flag.Int("degree", 6, "degree of the random-regular and small-world graphs")
+	hubs := flag.Int("hubs", 3, "amount of hubs of the star")
+	kind := flag.String("kind", string(graphkit.RandomRegular), "kind of the graph")
+	rewire := flag.Float64("rewire", 0.1, "rewiring probability of the small-world graph")
+	seed := flag.Int64("seed", 0, "seed of the graph")
+	flag.Parse()
+
+	cfg := graphkit.Config{
+		Kind:   graphkit.Kind(*kind),
+		Degree: *degree,
+		Rewire: *rewire,
+		Hubs:   *hubs,
+		Seed:   *seed,
+	}
+	err := printGraph(cfg, *nodes)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+}
+
+func printGraph(cfg graphkit.Config, n int) error {
+	g, err := cfg.Generate(n)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Config    graphkit.Config
+		MaxDegree int
+		Peers     [][]int
+	}{cfg, cfg.MaxDegree(n), g.Peers})
+}
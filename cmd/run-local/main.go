@@ -0,0 +1,103 @@
+/*
+run-local runs a composition in a single process, without the testground daemon,
+docker or a sync service (see testkit/localkit). It is meant for quick checks of the
+logic of a test case with a handful of instances:
+
+	go run ./cmd/run-local -composition compositions/local-docker/big-blocks/002-da-sync-4.toml
+
+The params are resolved like testground does: the defaults of manifest.toml, then
+the global test params of the composition and then the ones of the group.
+*/
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/celestiaorg/test-infra/cmd/internal/manifest"
+	"github.com/celestiaorg/test-infra/testkit/localkit"
+	"github.com/celestiaorg/test-infra/tests/registry"
+)
+
+func main() {
+	root := flag.String("root", ".", "root directory of the repository")
+	path := flag.String("composition", "", "path of the composition to run")
+	outputs := flag.String("outputs", "", "directory of the outputs of the instances, a temporary one by default")
+	timeout := flag.Duration("timeout", time.Hour, "maximum duration of the run")
+	flag.Parse()
+
+	if *path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	comp, testcase, err := load(*root, *path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	comp.OutputsPath = *outputs
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	results, err := localkit.Run(ctx, comp, testcase)
+	for _, r := range results {
+		status := "ok"
+		if r.Err != nil {
+			status = r.Err.Error()
+		}
+		fmt.Printf("%s/%d: %s\n", r.GroupID, r.GroupSeq, status)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// load reads the composition at path and looks up the implementation of its test case
+func load(root, path string) (localkit.Composition, interface{}, error) {
+	c, err := manifest.ReadComposition(path)
+	if err != nil {
+		return localkit.Composition{}, nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	m, err := manifest.Read(filepath.Join(root, "manifest.toml"))
+	if err != nil {
+		return localkit.Composition{}, nil, err
+	}
+
+	tc, ok := m.TestCase(c.Global.Case)
+	if !ok {
+		return localkit.Composition{}, nil, fmt.Errorf("test case %q is not declared in manifest.toml", c.Global.Case)
+	}
+	testcase, ok := registry.TestCases[tc.Name]
+	if !ok {
+		return localkit.Composition{}, nil, fmt.Errorf("test case %q is not registered", tc.Name)
+	}
+
+	comp := localkit.Composition{
+		Plan:   c.Global.Plan,
+		Case:   tc.Name,
+		Params: tc.Defaults(),
+	}
+	for k, v := range c.Global.Run.TestParams {
+		comp.Params[k] = fmt.Sprint(v)
+	}
+	for _, g := range c.Groups {
+		group := localkit.Group{
+			ID:        g.ID,
+			Instances: g.Instances.Count,
+			Params:    make(map[string]string, len(g.Run.TestParams)),
+		}
+		for k, v := range g.Run.TestParams {
+			group.Params[k] = fmt.Sprint(v)
+		}
+		comp.Groups = append(comp.Groups, group)
+	}
+	return comp, testcase, nil
+}
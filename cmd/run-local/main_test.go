@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/celestiaorg/test-infra/testkit/localkit"
+)
+
+// TestDASync runs 002-da-sync with a validator, a bridge, a full and a light node,
+// like `make run-local` does. It runs a chain for minutes, so it's skipped in short mode:
+//
+//	go test ./cmd/run-local -run TestDASync -timeout 15m
+func TestDASync(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs a chain and the DA nodes for minutes")
+	}
+	// the homes of the nodes are at the root, like in the containers of testground
+	dir, err := os.MkdirTemp("/", ".run-local-*")
+	if err != nil {
+		t.Skipf("the homes of the nodes can't be created: %s", err)
+	}
+	os.Remove(dir)
+
+	root := filepath.Join("..", "..")
+	comp, testcase, err := load(root, filepath.Join(root, "compositions", "local-docker", "big-blocks", "002-da-sync-4.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	comp.OutputsPath = t.TempDir()
+
+	ctx := context.Background()
+	if deadline, ok := t.Deadline(); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline.Add(-10*time.Second))
+		defer cancel()
+	}
+
+	// the error joins the errors of the failed instances
+	_, err = localkit.Run(ctx, comp, testcase)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,471 @@
+/*
+validate-manifest checks that manifest.toml, the compositions and the code agree with each other:
+
+  - every test case of the manifest is registered in tests/registry and vice versa
+  - every composition runs a test case of the manifest and only sets params declared for it
+  - every param read through runenv.*Param by the code reachable from a test case is declared
+    for it, unless the code checks it with runenv.IsParamSet
+  - every param of the typed params (see testkit/paramkit) reachable from a test case is declared
+    for it, unless the field has a default value
+
+It only parses the sources, so it doesn't need to build the test plans:
+
+	go run ./cmd/validate-manifest -root .
+
+The same checks run against the repository in `go test ./cmd/validate-manifest`.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/celestiaorg/test-infra/cmd/internal/manifest"
+)
+
+const (
+	modulePath   = "github.com/celestiaorg/test-infra"
+	registryFile = "tests/registry/registry.go"
+	registryVar  = "TestCases"
+	paramTag     = "param"
+	defaultTag   = "default"
+)
+
+// requiredGetters are the runenv getters that fail when the param is not set
+var requiredGetters = map[string]bool{
+	"StringParam":      true,
+	"IntParam":         true,
+	"BooleanParam":     true,
+	"FloatParam":       true,
+	"JSONParam":        true,
+	"DurationParam":    true,
+	"SizeParam":        true,
+	"StringArrayParam": true,
+}
+
+// decl is a top-level declaration of a package: a func, a method, a var or a type
+type decl struct {
+	// refs are the keys of the funcs, vars and types referenced by the declaration
+	refs []string
+	// methods are the names of the methods called on values, which can't be
+	// resolved without type checking, so every method with that name is reachable
+	methods  []string
+	required map[string]string // param -> position of the first read
+	optional map[string]bool
+}
+
+// index holds the declarations of all the packages of the module
+type index struct {
+	// decls are keyed by "importpath.Name"
+	decls map[string]*decl
+	// methods are keyed by the method name
+	methods map[string][]*decl
+}
+
+func main() {
+	root := flag.String("root", ".", "root directory of the repository")
+	flag.Parse()
+
+	problems, err := validate(*root)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	for _, p := range problems {
+		fmt.Println(p)
+	}
+	if len(problems) > 0 {
+		fmt.Printf("%d problems found\n", len(problems))
+		os.Exit(1)
+	}
+	fmt.Println("manifest, compositions and test cases are consistent")
+}
+
+func validate(root string) ([]string, error) {
+	var problems []string
+
+	declared, err := readManifest(filepath.Join(root, "manifest.toml"))
+	if err != nil {
+		return nil, err
+	}
+
+	registered, err := readRegistry(filepath.Join(root, registryFile))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range sortedKeys(declared) {
+		if _, ok := registered[name]; !ok {
+			problems = append(problems, fmt.Sprintf("manifest: test case %q is not registered in %s", name, registryFile))
+		}
+	}
+	for _, name := range sortedKeys(registered) {
+		if _, ok := declared[name]; !ok {
+			problems = append(problems, fmt.Sprintf("%s: test case %q is not declared in manifest.toml", registryFile, name))
+		}
+	}
+
+	compProblems, err := validateCompositions(filepath.Join(root, "compositions"), declared)
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, compProblems...)
+
+	idx, err := buildIndex(root)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range sortedKeys(registered) {
+		params, ok := declared[name]
+		if !ok {
+			continue
+		}
+
+		required, optional := idx.reachableParams(registered[name])
+		for _, param := range sortedKeys(required) {
+			if _, ok := params[param]; !ok && !optional[param] {
+				problems = append(problems, fmt.Sprintf(
+					"%s: param %q is read by test case %q but not declared in manifest.toml",
+					required[param], param, name,
+				))
+			}
+		}
+	}
+
+	return problems, nil
+}
+
+// readManifest returns the declared params of every test case
+func readManifest(path string) (map[string]map[string]interface{}, error) {
+	m, err := manifest.Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	declared := make(map[string]map[string]interface{}, len(m.Testcases))
+	for _, tc := range m.Testcases {
+		declared[tc.Name] = tc.Params
+	}
+	return declared, nil
+}
+
+// readRegistry returns the "importpath.Func" implementing every registered test case
+func readRegistry(path string) (map[string]string, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	imports := make(map[string]string)
+	for _, imp := range f.Imports {
+		importPath, _ := strconv.Unquote(imp.Path.Value)
+		name := filepath.Base(importPath)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[name] = importPath
+	}
+
+	registered := make(map[string]string)
+	ast.Inspect(f, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || len(spec.Names) != 1 || spec.Names[0].Name != registryVar || len(spec.Values) != 1 {
+			return true
+		}
+
+		lit, ok := spec.Values[0].(*ast.CompositeLit)
+		if !ok {
+			return false
+		}
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			key, ok := kv.Key.(*ast.BasicLit)
+			if !ok {
+				continue
+			}
+			sel, ok := kv.Value.(*ast.SelectorExpr)
+			if !ok {
+				continue
+			}
+			pkg, ok := sel.X.(*ast.Ident)
+			if !ok {
+				continue
+			}
+
+			name, _ := strconv.Unquote(key.Value)
+			registered[name] = imports[pkg.Name] + "." + sel.Sel.Name
+		}
+		return false
+	})
+
+	if len(registered) == 0 {
+		return nil, fmt.Errorf("no test case found in %s.%s", path, registryVar)
+	}
+	return registered, nil
+}
+
+func validateCompositions(dir string, declared map[string]map[string]interface{}) ([]string, error) {
+	var problems []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || filepath.Ext(path) != ".toml" {
+			return err
+		}
+
+		c, err := manifest.ReadComposition(path)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid composition: %v", path, err))
+			return nil
+		}
+
+		params, ok := declared[c.Global.Case]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: test case %q is not declared in manifest.toml", path, c.Global.Case))
+			return nil
+		}
+
+		for _, param := range sortedKeys(c.Global.Run.TestParams) {
+			if _, ok := params[param]; !ok {
+				problems = append(problems, fmt.Sprintf(
+					"%s: unknown param %q in global test_params of test case %q", path, param, c.Global.Case,
+				))
+			}
+		}
+		for _, g := range c.Groups {
+			for _, param := range sortedKeys(g.Run.TestParams) {
+				if _, ok := params[param]; !ok {
+					problems = append(problems, fmt.Sprintf(
+						"%s: unknown param %q in test_params of group %q of test case %q", path, param, g.ID, c.Global.Case,
+					))
+				}
+			}
+		}
+		return nil
+	})
+	return problems, err
+}
+
+// reachableParams collects the params read by all the declarations reachable from the given one
+func (idx *index) reachableParams(key string) (map[string]string, map[string]bool) {
+	required := make(map[string]string)
+	optional := make(map[string]bool)
+
+	visited := make(map[*decl]bool)
+	queue := []*decl{idx.decls[key]}
+	for len(queue) > 0 {
+		d := queue[0]
+		queue = queue[1:]
+		if d == nil || visited[d] {
+			continue
+		}
+		visited[d] = true
+
+		for param, pos := range d.required {
+			if _, ok := required[param]; !ok {
+				required[param] = pos
+			}
+		}
+		for param := range d.optional {
+			optional[param] = true
+		}
+
+		for _, ref := range d.refs {
+			queue = append(queue, idx.decls[ref])
+		}
+		for _, name := range d.methods {
+			queue = append(queue, idx.methods[name]...)
+		}
+	}
+
+	return required, optional
+}
+
+// buildIndex parses all the packages of the module, except the commands
+func buildIndex(root string) (*index, error) {
+	idx := &index{
+		decls:   make(map[string]*decl),
+		methods: make(map[string][]*decl),
+	}
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if rel == "cmd" || strings.HasPrefix(info.Name(), ".") && rel != "." {
+			return filepath.SkipDir
+		}
+
+		importPath := modulePath
+		if rel != "." {
+			importPath += "/" + filepath.ToSlash(rel)
+		}
+		return idx.parsePackage(path, importPath)
+	})
+	return idx, err
+}
+
+// parsePackage indexes the declarations of the package in dir
+func (idx *index) parsePackage(dir, importPath string) error {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range pkgs {
+		if p.Name == "main" && importPath != modulePath {
+			continue
+		}
+
+		// top-level names of the package, to resolve the references within the package
+		toplevel := make(map[string]bool)
+		for _, f := range p.Files {
+			for name, obj := range f.Scope.Objects {
+				if obj.Kind == ast.Fun || obj.Kind == ast.Var || obj.Kind == ast.Typ {
+					toplevel[name] = true
+				}
+			}
+		}
+
+		for _, f := range p.Files {
+			imports := make(map[string]string)
+			for _, imp := range f.Imports {
+				path, _ := strconv.Unquote(imp.Path.Value)
+				name := filepath.Base(path)
+				if imp.Name != nil {
+					name = imp.Name.Name
+				}
+				imports[name] = path
+			}
+
+			for _, d := range f.Decls {
+				switch d := d.(type) {
+				case *ast.FuncDecl:
+					if d.Recv != nil {
+						// the receiver is left out, as reaching a method by its name
+						// doesn't mean that every type declaring it is reachable
+						body := &ast.FuncDecl{Name: d.Name, Type: d.Type, Body: d.Body}
+						dc := newDecl(fset, body, importPath, imports, toplevel)
+						idx.methods[d.Name.Name] = append(idx.methods[d.Name.Name], dc)
+						continue
+					}
+					dc := newDecl(fset, d, importPath, imports, toplevel)
+					idx.decls[importPath+"."+d.Name.Name] = dc
+				case *ast.GenDecl:
+					switch d.Tok {
+					case token.VAR:
+						for _, spec := range d.Specs {
+							vs := spec.(*ast.ValueSpec)
+							dc := newDecl(fset, vs, importPath, imports, toplevel)
+							for _, name := range vs.Names {
+								idx.decls[importPath+"."+name.Name] = dc
+							}
+						}
+					case token.TYPE:
+						for _, spec := range d.Specs {
+							ts := spec.(*ast.TypeSpec)
+							idx.decls[importPath+"."+ts.Name.Name] = newDecl(fset, ts, importPath, imports, toplevel)
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// newDecl collects the references and the param reads of the node
+func newDecl(
+	fset *token.FileSet,
+	node ast.Node,
+	importPath string,
+	imports map[string]string,
+	toplevel map[string]bool,
+) *decl {
+	d := &decl{
+		required: make(map[string]string),
+		optional: make(map[string]bool),
+	}
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if x, ok := n.X.(*ast.Ident); ok {
+				if path, ok := imports[x.Name]; ok && x.Obj == nil {
+					d.refs = append(d.refs, path+"."+n.Sel.Name)
+					return false
+				}
+			}
+			d.methods = append(d.methods, n.Sel.Name)
+		case *ast.Ident:
+			if toplevel[n.Name] {
+				d.refs = append(d.refs, importPath+"."+n.Name)
+			}
+		case *ast.Field:
+			if n.Tag == nil {
+				return true
+			}
+			tag, _ := strconv.Unquote(n.Tag.Value)
+			param, ok := reflect.StructTag(tag).Lookup(paramTag)
+			if !ok {
+				return true
+			}
+			if _, ok := reflect.StructTag(tag).Lookup(defaultTag); ok {
+				d.optional[param] = true
+			} else if _, ok := d.required[param]; !ok {
+				d.required[param] = fset.Position(n.Tag.Pos()).String()
+			}
+		case *ast.CallExpr:
+			sel, ok := n.Fun.(*ast.SelectorExpr)
+			if !ok || len(n.Args) != 1 {
+				return true
+			}
+			lit, ok := n.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+
+			param, _ := strconv.Unquote(lit.Value)
+			switch {
+			case sel.Sel.Name == "IsParamSet":
+				d.optional[param] = true
+			case requiredGetters[sel.Sel.Name]:
+				if _, ok := d.required[param]; !ok {
+					d.required[param] = fset.Position(lit.Pos()).String()
+				}
+			}
+		}
+		return true
+	})
+	return d
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
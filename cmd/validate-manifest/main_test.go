@@ -0,0 +1,149 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRepository fails on any mismatch between manifest.toml, the compositions and the code
+func TestRepository(t *testing.T) {
+	problems, err := validate(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range problems {
+		t.Error(p)
+	}
+}
+
+const (
+	testRegistry = `package registry
+
+import "github.com/celestiaorg/test-infra/tests/plans/p"
+
+var TestCases = map[string]interface{}{
+	"case": p.Run,
+}
+`
+	testPlan = `package p
+
+func Run(runenv *runtime.RunEnv) error {
+	_ = runenv.IntParam("size")
+	if runenv.IsParamSet("optional") {
+		_ = runenv.IntParam("optional")
+	}
+	return nil
+}
+`
+	testManifest = `name = "test"
+
+[[testcases]]
+name = "case"
+instances = { min = 1, max = 1, default = 1 }
+    [testcases.params]
+    size = { type = "int", default = 1 }
+`
+	testComposition = `[metadata]
+  name = "case"
+
+[global]
+  plan = "test"
+  case = "case"
+  total_instances = 1
+
+[global.run.test_params]
+  size = "2"
+
+[[groups]]
+  id = "group"
+  [groups.instances]
+    count = 1
+`
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		// files replace the files of the consistent tree
+		files map[string]string
+		// problems are substrings of the expected problems, in order
+		problems []string
+	}{
+		{
+			name: "consistent",
+		},
+		{
+			name: "unregistered test case",
+			files: map[string]string{
+				"manifest.toml": testManifest + strings.ReplaceAll(testManifest[len(`name = "test"`):], `"case"`, `"other"`),
+			},
+			problems: []string{`test case "other" is not registered`},
+		},
+		{
+			name: "undeclared test case",
+			files: map[string]string{
+				"tests/registry/registry.go": strings.Replace(testRegistry, "}\n", "\t\"other\": p.Run,\n}\n", 1),
+			},
+			problems: []string{`test case "other" is not declared in manifest.toml`},
+		},
+		{
+			name: "composition of an unknown test case",
+			files: map[string]string{
+				"compositions/case.toml": strings.Replace(testComposition, `case = "case"`, `case = "other"`, 1),
+			},
+			problems: []string{`test case "other" is not declared in manifest.toml`},
+		},
+		{
+			name: "composition setting an undeclared param",
+			files: map[string]string{
+				"compositions/case.toml": strings.Replace(testComposition, `size = "2"`, `size = "2"`+"\n  other = \"3\"", 1),
+			},
+			problems: []string{`unknown param "other" in global test_params`},
+		},
+		{
+			name: "param read but not declared",
+			files: map[string]string{
+				"tests/plans/p/p.go": strings.Replace(testPlan, `IntParam("size")`, `IntParam("missing")`, 1),
+			},
+			problems: []string{`param "missing" is read by test case "case"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			files := map[string]string{
+				"manifest.toml":              testManifest,
+				"tests/registry/registry.go": testRegistry,
+				"tests/plans/p/p.go":         testPlan,
+				"compositions/case.toml":     testComposition,
+			}
+			for name, content := range tt.files {
+				files[name] = content
+			}
+			for name, content := range files {
+				path := filepath.Join(root, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			problems, err := validate(root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(problems) != len(tt.problems) {
+				t.Fatalf("got problems %q, want %q", problems, tt.problems)
+			}
+			for i, want := range tt.problems {
+				if !strings.Contains(problems[i], want) {
+					t.Errorf("problem %d is %q, want it to contain %q", i, problems[i], want)
+				}
+			}
+		})
+	}
+}
@@ -2,24 +2,26 @@ package appkit
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"syscall"
+	"time"
 
 	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/p2p/pex"
 
-	"github.com/celestiaorg/celestia-app/app"
-	appcmd "github.com/celestiaorg/celestia-app/cmd/celestia-appd/cmd"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/client/keys"
-	svrcmd "github.com/cosmos/cosmos-sdk/server/cmd"
-	"github.com/spf13/cobra"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
 	"github.com/spf13/viper"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	coretypes "github.com/tendermint/tendermint/rpc/core/types"
@@ -38,46 +40,54 @@ type AppKit struct {
 	AccountName    string
 	ValopAddress   string
 	ChainId        string
-	Cmd            *cobra.Command
+	// PFBStrategy and FundStrategy decide the gas and fees of PayForBlob and FundAccounts
+	PFBStrategy  TxStrategy
+	FundStrategy TxStrategy
+
+	client *Client
+	// node is the process of the node started by StartNode and stopped is closed when it exits
+	node    *os.Process
+	stopped chan struct{}
 }
 
+var (
+	// DefaultPFBStrategy is used by PayForBlob unless a different strategy is set
+	DefaultPFBStrategy = FixedStrategy(1000000000, 100000000000)
+	// DefaultFundStrategy is used by FundAccounts unless a different strategy is set
+	DefaultFundStrategy = FixedStrategy(2000000, 100000)
+)
+
 func wrapFlag(str string) string {
 	return fmt.Sprintf("--%s", str)
 }
 
 func New(path, chainId string) *AppKit {
 	return &AppKit{
-		Home:    path,
-		ChainId: chainId,
-		Cmd:     appcmd.NewRootCmd(),
+		Home:         path,
+		ChainId:      chainId,
+		PFBStrategy:  DefaultPFBStrategy,
+		FundStrategy: DefaultFundStrategy,
 	}
 }
 
+// execCmd runs `celestia-appd` with the args in a child process and returns its output,
+// see command. The commands don't share the stdout of the instance with the other
+// instances running in the same process, like the ones of localkit
 func (ak *AppKit) execCmd(args []string) (output string, err error) {
-	ak.Cmd.ResetFlags()
-
-	ak.m.Lock()
-	scrapStdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-
-	out := new(bytes.Buffer)
-	ak.Cmd.Println(out)
-	ak.Cmd.SetArgs(args)
-	if err := svrcmd.Execute(ak.Cmd, appcmd.EnvPrefix, app.DefaultNodeHome); err != nil {
+	cmd, err := command(args...)
+	if err != nil {
 		return "", err
 	}
 
-	w.Close()
-	outStr, err := io.ReadAll(r)
+	out, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.Stdout = out
+	cmd.Stderr = stderr
+	err = cmd.Run()
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("celestia-appd %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
 	}
-	os.Stdout = scrapStdout
-	ak.m.Unlock()
 
-	output = string(outStr)
-	output = strings.ReplaceAll(output, "\n", "")
+	output = strings.ReplaceAll(out.String(), "\n", "")
 	return output, nil
 }
 
@@ -178,34 +188,13 @@ func (ak *AppKit) SignGenTx(accName, amount, krbackend, krpath string) (string,
 		krpath,
 	}
 
-	ak.Cmd.ResetFlags()
-
-	ak.m.Lock()
-
-	ak.Cmd.SetArgs(args)
-	if err := svrcmd.Execute(ak.Cmd, appcmd.EnvPrefix, app.DefaultNodeHome); err != nil {
-		return "", err
-	}
-
-	ak.m.Unlock()
-
-	return "", nil
+	_, err := ak.execCmd(args)
+	return "", err
 }
 
 func (ak *AppKit) CollectGenTxs() (string, error) {
-	args := []string{"collect-gentxs", wrapFlag(flags.FlagHome), ak.Home}
-	ak.Cmd.ResetFlags()
-
-	ak.m.Lock()
-
-	ak.Cmd.SetArgs(args)
-	if err := svrcmd.Execute(ak.Cmd, appcmd.EnvPrefix, app.DefaultNodeHome); err != nil {
-		return "", err
-	}
-
-	ak.m.Unlock()
-
-	return "", nil
+	_, err := ak.execCmd([]string{"collect-gentxs", wrapFlag(flags.FlagHome), ak.Home})
+	return "", err
 }
 
 func (ak *AppKit) GetNodeId() (string, error) {
@@ -214,101 +203,135 @@ func (ak *AppKit) GetNodeId() (string, error) {
 	)
 }
 
+// StartNode runs `celestia-appd start` in a child process until it stops, see StopNode
 func (ak *AppKit) StartNode(loglvl string) error {
-	ak.Cmd.ResetFlags()
+	// the log is appended to, so the logs before a restart of the node are kept
+	log, err := os.OpenFile(filepath.Join("/var/log", "node.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return err
+	}
+	defer log.Close()
 
-	// SetErr: send the error logs to stderr stream.
-	ak.Cmd.SetErr(os.Stderr)
-	ak.Cmd.SetArgs(
-		[]string{
-			"start",
-			wrapFlag(flags.FlagHome),
-			ak.Home,
-			wrapFlag(flags.FlagLogLevel),
-			loglvl,
-			wrapFlag(flags.FlagLogFormat),
-			"json",
-		},
+	cmd, err := command(
+		"start",
+		wrapFlag(flags.FlagHome),
+		ak.Home,
+		wrapFlag(flags.FlagLogLevel),
+		loglvl,
+		wrapFlag(flags.FlagLogFormat),
+		"json",
 	)
-	log, err := os.Create(filepath.Join("/var/log", "node.log"))
 	if err != nil {
 		return err
 	}
+	cmd.Stdout = log
+	cmd.Stderr = log
 
-	ak.Cmd.SetErr(log)
+	err = cmd.Start()
+	if err != nil {
+		return err
+	}
 
-	return svrcmd.Execute(ak.Cmd, appcmd.EnvPrefix, app.DefaultNodeHome)
+	stopped := make(chan struct{})
+	ak.m.Lock()
+	ak.node = cmd.Process
+	ak.stopped = stopped
+	ak.m.Unlock()
+	defer close(stopped)
+
+	return cmd.Wait()
 }
 
-func (ak *AppKit) FundAccounts(accAdr, amount, krbackend, krpath string, accAddrs ...string) error {
+// StopNode stops the node started by StartNode and waits until StartNode returns.
+// The node quits on SIGTERM like celestia-appd does, and is killed if it's still
+// running at the end of the context
+func (ak *AppKit) StopNode(ctx context.Context) error {
 	ak.m.Lock()
-	defer ak.m.Unlock()
-	args := []string{"tx", "bank", "multi-send", accAdr}
-	args = append(args, accAddrs...)
-	args = append(args, amount,
-		wrapFlag(flags.FlagBroadcastMode), flags.BroadcastBlock,
-		wrapFlag(flags.FlagSkipConfirmation),
-		wrapFlag(flags.FlagGas), "2000000",
-		wrapFlag(flags.FlagFees), "100000utia",
-		wrapFlag(flags.FlagKeyringBackend),
-		krbackend,
-		wrapFlag(flags.FlagChainID),
-		ak.ChainId,
-		wrapFlag(flags.FlagHome),
-		ak.Home,
-		wrapFlag(flags.FlagKeyringDir),
-		krpath,
-	)
+	node, stopped := ak.node, ak.stopped
+	ak.m.Unlock()
+	if stopped == nil {
+		return fmt.Errorf("the node is not started")
+	}
+
+	select {
+	case <-stopped:
+		return fmt.Errorf("the node has already stopped")
+	default:
+	}
 
-	ak.Cmd.ResetFlags()
-	ak.Cmd.SetArgs(args)
+	err := node.Signal(syscall.SIGTERM)
+	if err != nil {
+		return err
+	}
 
-	return svrcmd.Execute(ak.Cmd, appcmd.EnvPrefix, app.DefaultNodeHome)
+	select {
+	case <-ctx.Done():
+		return errors.Join(fmt.Errorf("stopping the node: %w", ctx.Err()), node.Kill())
+	case <-stopped:
+		return nil
+	}
 }
 
+// FundAccounts sends the amount from accAdr to each of the accAddrs in a single multi-send tx
+func (ak *AppKit) FundAccounts(accAdr, amount, krbackend, krpath string, accAddrs ...string) error {
+	c, err := ak.Client(krbackend, krpath)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.MultiSend(context.Background(), accAdr, amount, TxOptions{Strategy: &ak.FundStrategy}, accAddrs...)
+	return err
+}
+
+// RegisterEVMAddress registers the evm address of the validator operator, signed by the `from` key
 func (ak *AppKit) RegisterEVMAddress(valoperAddr, evmAddr, krbackend, krpath, from string) error {
-	args := []string{"tx", "qgb", "register", valoperAddr, evmAddr}
-	args = append(args,
-		wrapFlag(flags.FlagBroadcastMode), flags.BroadcastSync,
-		wrapFlag(flags.FlagSkipConfirmation),
-		wrapFlag(flags.FlagFees), "100000utia",
-		wrapFlag(flags.FlagKeyringBackend), krbackend,
-		wrapFlag(flags.FlagChainID), ak.ChainId,
-		wrapFlag(flags.FlagHome), ak.Home,
-		wrapFlag(flags.FlagKeyringDir), krpath,
-		wrapFlag(flags.FlagFrom), from,
+	c, err := ak.Client(krbackend, krpath)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.RegisterEVMAddress(
+		context.Background(),
+		from,
+		valoperAddr,
+		evmAddr,
+		TxOptions{Gas: 200000, Fees: "100000utia", Mode: sdktx.BroadcastMode_BROADCAST_MODE_SYNC},
 	)
-	fmt.Println(args)
-	ak.m.Lock()
-	defer ak.m.Unlock()
-	ak.Cmd.ResetFlags()
-	ak.Cmd.SetArgs(args)
+	return err
+}
+
+// PayForBlob submits a random blob of msg bytes drawn from r and waits for it to be included in a block
+func (ak *AppKit) PayForBlob(r *rand.Rand, accAdr string, msg int, krbackend, krpath string) (*PayForBlobResult, error) {
+	c, err := ak.Client(krbackend, krpath)
+	if err != nil {
+		return nil, err
+	}
 
-	return svrcmd.Execute(ak.Cmd, appcmd.EnvPrefix, app.DefaultNodeHome)
+	start := time.Now()
+	resp, err := c.SubmitRandomBlob(context.Background(), r, accAdr, msg, TxOptions{Strategy: &ak.PFBStrategy})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPayForBlobResult(resp, []int{msg}, time.Since(start)), nil
 }
 
-func (ak *AppKit) PayForBlob(accAdr string, msg int, krbackend, krpath string) error {
+// Client returns the native gRPC client of the validator running in this instance.
+// It is created on the first call, using the keyring at krpath
+func (ak *AppKit) Client(krbackend, krpath string) (*Client, error) {
 	ak.m.Lock()
 	defer ak.m.Unlock()
-	ak.Cmd.ResetFlags()
-	ak.Cmd.SetArgs([]string{
-		"tx", "blob", "TestRandBlob", fmt.Sprint(msg),
-		wrapFlag(flags.FlagFrom), accAdr,
-		wrapFlag(flags.FlagBroadcastMode), flags.BroadcastBlock,
-		wrapFlag(flags.FlagSkipConfirmation),
-		wrapFlag(flags.FlagGas), "1000000000",
-		wrapFlag(flags.FlagFees), "100000000000utia",
-		wrapFlag(flags.FlagKeyringBackend),
-		krbackend,
-		wrapFlag(flags.FlagChainID),
-		ak.ChainId,
-		wrapFlag(flags.FlagHome),
-		ak.Home,
-		wrapFlag(flags.FlagKeyringDir),
-		krpath,
-	})
 
-	return svrcmd.Execute(ak.Cmd, appcmd.EnvPrefix, app.DefaultNodeHome)
+	if ak.client != nil {
+		return ak.client, nil
+	}
+
+	c, err := NewClient(ak.ChainId, krbackend, krpath, DefaultGRPCAddr)
+	if err != nil {
+		return nil, err
+	}
+	ak.client = c
+	return c, nil
 }
 
 func GetGenesisState(uri string) (*coretypes.ResultGenesis, error) {
@@ -0,0 +1,119 @@
+package appkit
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/testground/sdk-go/runtime"
+)
+
+// SizeDistribution draws the sizes of the blobs submitted in a PFB
+type SizeDistribution interface {
+	Size(r *rand.Rand) int
+}
+
+// FixedSize always draws the same size
+type FixedSize int
+
+func (s FixedSize) Size(*rand.Rand) int {
+	return int(s)
+}
+
+// UniformSize draws sizes uniformly in [Min, Max]
+type UniformSize struct {
+	Min, Max int
+}
+
+func (s UniformSize) Size(r *rand.Rand) int {
+	return s.Min + r.Intn(s.Max-s.Min+1)
+}
+
+// ExponentialSize draws sizes exponentially distributed around Mean, capped to [1, Max]
+type ExponentialSize struct {
+	Mean, Max int
+}
+
+func (s ExponentialSize) Size(r *rand.Rand) int {
+	size := int(math.Ceil(r.ExpFloat64() * float64(s.Mean)))
+	if size < 1 {
+		return 1
+	}
+	if s.Max > 0 && size > s.Max {
+		return s.Max
+	}
+	return size
+}
+
+// BlobPlan describes the blobs of a single PFB: Blobs blobs spread across Namespaces namespaces
+type BlobPlan struct {
+	Blobs      int
+	Namespaces int
+	Sizes      SizeDistribution
+}
+
+// BlobSpec is a single planned blob, Namespace indexes the namespaces of the plan
+type BlobSpec struct {
+	Namespace int
+	Size      int
+}
+
+// Specs draws the namespace and the size of every blob of the plan.
+// Blobs are assigned to the namespaces round-robin, so every namespace gets at least one blob
+func (p BlobPlan) Specs(r *rand.Rand) []BlobSpec {
+	specs := make([]BlobSpec, p.Blobs)
+	for i := range specs {
+		specs[i] = BlobSpec{
+			Namespace: i % p.Namespaces,
+			Size:      p.Sizes.Size(r),
+		}
+	}
+	return specs
+}
+
+// BlobPlanFromParams reads the plan from the composition params:
+//
+//   - blobs: amount of blobs in a PFB, defaults to 1
+//   - namespaces: amount of namespaces the blobs are spread across, defaults to 1
+//   - blob-size-dist: "fixed" (msg-size), "uniform" (blob-size-min, blob-size-max)
+//     or "exponential" (mean msg-size, capped to blob-size-max)
+func BlobPlanFromParams(runenv *runtime.RunEnv) (BlobPlan, error) {
+	plan := BlobPlan{Blobs: 1, Namespaces: 1}
+	if runenv.IsParamSet("blobs") {
+		plan.Blobs = runenv.IntParam("blobs")
+	}
+	if runenv.IsParamSet("namespaces") {
+		plan.Namespaces = runenv.IntParam("namespaces")
+	}
+	if plan.Blobs < 1 || plan.Namespaces < 1 || plan.Namespaces > plan.Blobs {
+		return BlobPlan{}, fmt.Errorf(
+			"invalid blob plan: %d blobs across %d namespaces, need 1 <= namespaces <= blobs",
+			plan.Blobs, plan.Namespaces,
+		)
+	}
+
+	switch dist := stringParam(runenv, "blob-size-dist", "fixed"); dist {
+	case "fixed":
+		plan.Sizes = FixedSize(runenv.IntParam("msg-size"))
+	case "uniform":
+		lo, hi := runenv.IntParam("blob-size-min"), runenv.IntParam("blob-size-max")
+		if lo < 1 || hi < lo {
+			return BlobPlan{}, fmt.Errorf("invalid uniform blob sizes [%d, %d]", lo, hi)
+		}
+		plan.Sizes = UniformSize{Min: lo, Max: hi}
+	case "exponential":
+		mean := runenv.IntParam("msg-size")
+		if mean < 1 {
+			return BlobPlan{}, fmt.Errorf("invalid exponential blob size mean %d", mean)
+		}
+		size := ExponentialSize{Mean: mean}
+		if runenv.IsParamSet("blob-size-max") {
+			size.Max = runenv.IntParam("blob-size-max")
+		}
+		plan.Sizes = size
+	default:
+		return BlobPlan{}, fmt.Errorf("unknown blob-size-dist %q, supported are fixed, uniform and exponential", dist)
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,386 @@
+package appkit
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	appns "github.com/celestiaorg/celestia-app/pkg/namespace"
+	blobtypes "github.com/celestiaorg/celestia-app/x/blob/types"
+	qgbtypes "github.com/celestiaorg/celestia-app/x/qgb/types"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	coretypes "github.com/tendermint/tendermint/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// DefaultGRPCAddr is the address of the gRPC server of the validator running
+// in the same instance
+const DefaultGRPCAddr = "127.0.0.1:9090"
+
+// TxResponse is the typed result of a broadcasted tx
+type TxResponse struct {
+	TxHash    string
+	Code      uint32
+	GasWanted int64
+	GasUsed   int64
+	Fee       string
+	Height    int64
+	RawLog    string
+}
+
+// TxOptions sets the gas limit and the fees paid by a tx
+type TxOptions struct {
+	Gas  uint64
+	Fees string
+	// Strategy, when set, estimates the gas limit and the fees instead of Gas and Fees
+	Strategy *TxStrategy
+	// Mode is the broadcasting mode, BROADCAST_MODE_BLOCK waits for the tx to be committed
+	Mode sdktx.BroadcastMode
+}
+
+// Client builds, signs and broadcasts txs through the cosmos-sdk tx factory and the
+// gRPC endpoint of a validator, without going through the celestia-appd cobra commands
+type Client struct {
+	m       sync.Mutex
+	chainId string
+	encCfg  encoding.Config
+	kr      keyring.Keyring
+	conn    *grpc.ClientConn
+
+	// accounts caches the account number and the next sequence of each signer,
+	// so sequential txs don't need to query the auth module every time
+	accounts map[string]authtypes.AccountI
+}
+
+// NewClient opens the keyring stored in krpath and connects to the gRPC server at grpcAddr
+func NewClient(chainId, krbackend, krpath, grpcAddr string) (*Client, error) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+
+	kr, err := keyring.New(sdk.KeyringServiceName(), krbackend, krpath, nil, encCfg.Codec)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.Dial(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		chainId:  chainId,
+		encCfg:   encCfg,
+		kr:       kr,
+		conn:     conn,
+		accounts: make(map[string]authtypes.AccountI),
+	}, nil
+}
+
+// Close closes the gRPC connection
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Broadcast signs the msgs with the `from` key, given by name or address, and broadcasts them as a single tx
+func (c *Client) Broadcast(ctx context.Context, from string, opts TxOptions, msgs ...sdk.Msg) (*TxResponse, error) {
+	return c.broadcast(ctx, from, opts, nil, msgs...)
+}
+
+// BroadcastBlobs submits a MsgPayForBlobs paying for the given blobs, signed by the `from` key
+func (c *Client) BroadcastBlobs(ctx context.Context, from string, opts TxOptions, blobs ...*tmproto.Blob) (*TxResponse, error) {
+	_, addr, err := c.signer(from)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := blobtypes.NewMsgPayForBlobs(addr.String(), blobs...)
+	if err != nil {
+		return nil, err
+	}
+	return c.broadcast(ctx, from, opts, blobs, msg)
+}
+
+// SubmitRandomBlob pays for a single blob of the given size with random data in a random namespace,
+// both drawn from r so a run with the same seed submits the same blobs
+func (c *Client) SubmitRandomBlob(ctx context.Context, r *rand.Rand, from string, size int, opts TxOptions) (*TxResponse, error) {
+	ns, err := randomBlobNamespace(r)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, size)
+	r.Read(data)
+	blob, err := blobtypes.NewBlob(ns, data, appconsts.ShareVersionZero)
+	if err != nil {
+		return nil, err
+	}
+	return c.BroadcastBlobs(ctx, from, opts, blob)
+}
+
+// randomBlobNamespace is appns.RandomBlobNamespace drawing from r
+func randomBlobNamespace(r *rand.Rand) (appns.Namespace, error) {
+	for {
+		id := make([]byte, appns.NamespaceVersionZeroIDSize)
+		r.Read(id)
+		ns, err := appns.NewV0(id)
+		if err != nil {
+			return appns.Namespace{}, err
+		}
+		if !ns.IsReserved() {
+			return ns, nil
+		}
+	}
+}
+
+// MultiSend sends the amount from the `from` account to each of the receivers in a single tx
+func (c *Client) MultiSend(ctx context.Context, from, amount string, opts TxOptions, to ...string) (*TxResponse, error) {
+	coins, err := sdk.ParseCoinsNormalized(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	_, fromAddr, err := c.signer(from)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]banktypes.Output, 0, len(to))
+	for _, addr := range to {
+		toAddr, err := sdk.AccAddressFromBech32(addr)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, banktypes.NewOutput(toAddr, coins))
+	}
+
+	total := coins.MulInt(sdk.NewInt(int64(len(to))))
+	msg := banktypes.NewMsgMultiSend([]banktypes.Input{banktypes.NewInput(fromAddr, total)}, outputs)
+
+	return c.Broadcast(ctx, from, opts, msg)
+}
+
+// RegisterEVMAddress registers the evm address of the validator operator in the qgb module
+func (c *Client) RegisterEVMAddress(ctx context.Context, from, valoperAddr, evmAddr string, opts TxOptions) (*TxResponse, error) {
+	valAddr, err := sdk.ValAddressFromBech32(valoperAddr)
+	if err != nil {
+		return nil, err
+	}
+	if !gethcommon.IsHexAddress(evmAddr) {
+		return nil, fmt.Errorf("invalid evm address %q", evmAddr)
+	}
+
+	msg := qgbtypes.NewMsgRegisterEVMAddress(valAddr, gethcommon.HexToAddress(evmAddr))
+	return c.Broadcast(ctx, from, opts, msg)
+}
+
+// broadcast signs and broadcasts the msgs, wrapping the tx in a BlobTx when blobs are given.
+// The cached sequence is dropped and the tx is retried once if the chain reports a mismatch
+func (c *Client) broadcast(
+	ctx context.Context,
+	from string,
+	opts TxOptions,
+	blobs []*tmproto.Blob,
+	msgs ...sdk.Msg,
+) (*TxResponse, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	_, addr, err := c.signer(from)
+	if err != nil {
+		return nil, err
+	}
+	key := addr.String()
+
+	resp, err := c.signAndBroadcast(ctx, from, opts, blobs, msgs...)
+	if err == nil && resp.Code == sdkerrors.ErrWrongSequence.ABCICode() {
+		delete(c.accounts, key)
+		resp, err = c.signAndBroadcast(ctx, from, opts, blobs, msgs...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != 0 {
+		delete(c.accounts, key)
+		return resp, fmt.Errorf("tx %s failed with code %d: %s", resp.TxHash, resp.Code, resp.RawLog)
+	}
+
+	acc := c.accounts[key]
+	err = acc.SetSequence(acc.GetSequence() + 1)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+func (c *Client) signAndBroadcast(
+	ctx context.Context,
+	from string,
+	opts TxOptions,
+	blobs []*tmproto.Blob,
+	msgs ...sdk.Msg,
+) (*TxResponse, error) {
+	record, addr, err := c.signer(from)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := c.account(ctx, addr.String())
+	if err != nil {
+		return nil, err
+	}
+
+	gas, fees := opts.Gas, opts.Fees
+	if opts.Strategy != nil {
+		req := GasRequest{
+			BlobSizes: make([]int, 0, len(blobs)),
+			Simulate: func(ctx context.Context) (uint64, error) {
+				txBytes, err := c.signTx(record, acc, 0, "", msgs...)
+				if err != nil {
+					return 0, err
+				}
+
+				res, err := sdktx.NewServiceClient(c.conn).Simulate(ctx, &sdktx.SimulateRequest{TxBytes: txBytes})
+				if err != nil {
+					return 0, err
+				}
+				return res.GasInfo.GasUsed, nil
+			},
+		}
+		for _, blob := range blobs {
+			req.BlobSizes = append(req.BlobSizes, len(blob.Data))
+		}
+
+		var fee sdkmath.Int
+		gas, fee, err = opts.Strategy.Estimate(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		fees = fmt.Sprintf("%sutia", fee)
+	}
+
+	txBytes, err := c.signTx(record, acc, gas, fees, msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(blobs) > 0 {
+		txBytes, err = coretypes.MarshalBlobTx(txBytes, blobs...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	mode := opts.Mode
+	if mode == sdktx.BroadcastMode_BROADCAST_MODE_UNSPECIFIED {
+		mode = sdktx.BroadcastMode_BROADCAST_MODE_BLOCK
+	}
+
+	res, err := sdktx.NewServiceClient(c.conn).BroadcastTx(ctx, &sdktx.BroadcastTxRequest{
+		TxBytes: txBytes,
+		Mode:    mode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TxResponse{
+		TxHash:    res.TxResponse.TxHash,
+		Code:      res.TxResponse.Code,
+		GasWanted: res.TxResponse.GasWanted,
+		GasUsed:   res.TxResponse.GasUsed,
+		Fee:       fees,
+		Height:    res.TxResponse.Height,
+		RawLog:    res.TxResponse.RawLog,
+	}, nil
+}
+
+// signTx builds the tx of the msgs with the given gas limit and fees and signs it with the key of the record
+func (c *Client) signTx(
+	record *keyring.Record,
+	acc authtypes.AccountI,
+	gas uint64,
+	fees string,
+	msgs ...sdk.Msg,
+) ([]byte, error) {
+	factory := tx.Factory{}.
+		WithChainID(c.chainId).
+		WithKeybase(c.kr).
+		WithTxConfig(c.encCfg.TxConfig).
+		WithAccountNumber(acc.GetAccountNumber()).
+		WithSequence(acc.GetSequence()).
+		WithGas(gas).
+		WithFees(fees).
+		WithSignMode(signing.SignMode_SIGN_MODE_DIRECT)
+
+	builder, err := factory.BuildUnsignedTx(msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Sign(factory, record.Name, builder, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.encCfg.TxConfig.TxEncoder()(builder.GetTx())
+}
+
+// signer returns the key and the address of `from`, which can be either a key name or an address
+func (c *Client) signer(from string) (*keyring.Record, sdk.AccAddress, error) {
+	var (
+		record *keyring.Record
+		err    error
+	)
+
+	addr, addrErr := sdk.AccAddressFromBech32(from)
+	if addrErr == nil {
+		record, err = c.kr.KeyByAddress(addr)
+	} else {
+		record, err = c.kr.Key(from)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	addr, err = record.GetAddress()
+	if err != nil {
+		return nil, nil, err
+	}
+	return record, addr, nil
+}
+
+// account returns the cached account of the given address or queries it from the auth module
+func (c *Client) account(ctx context.Context, addr string) (authtypes.AccountI, error) {
+	if acc, ok := c.accounts[addr]; ok {
+		return acc, nil
+	}
+
+	res, err := authtypes.NewQueryClient(c.conn).Account(ctx, &authtypes.QueryAccountRequest{Address: addr})
+	if err != nil {
+		return nil, err
+	}
+
+	var acc authtypes.AccountI
+	err = c.encCfg.InterfaceRegistry.UnpackAny(res.Account, &acc)
+	if err != nil {
+		return nil, err
+	}
+
+	c.accounts[addr] = acc
+	return acc, nil
+}
@@ -8,7 +8,23 @@ scenario.
 To start using the wrapped style CLI, you need to initialise the struct AppKit.
 A returned cmd contains functions that returns an output from StdOut pipeline
 (e.g. like the end user will see in the terminal) as well as errors if something
-bad happened while executing a command
+bad happened while executing a command. The commands and the node run in child
+processes of the binary of the instance, so the instances sharing a process
+(see localkit) don't share their stdout
+
+Txs don't need to go through the CLI: Client builds, signs and broadcasts them
+through the cosmos-sdk tx factory and the gRPC endpoint of the validator, returning
+a typed TxResponse (hash, code, gas used and height). PayForBlob, FundAccounts and
+RegisterEVMAddress of AppKit use it under the hood. The gas limit and fees of
+the txs are decided by a TxStrategy, selected from the composition params with
+TxStrategyFromParams (fixed, simulate, blob size based gas and fixed or gas price fees).
+These params are meant for the PFBs, FundAccounts uses DefaultFundStrategy unless set otherwise
+
+The genesis is customized with Genesis and ApplyGenesis: consensus params (max block bytes,
+time iota), module params (max square size of the blob module, unbonding time) and the
+funded accounts. Stakes decides unequal self-delegations of the validators, and
+PrefundedKey derives the accounts of the DA nodes from the seed of the run, so they
+can be funded in the genesis before the nodes exist
 
 Other functionality in appkit is an easy-to-modify values in .toml(e.g. config.toml)
 This can help the test user to modify what is needed for a scenario without a
@@ -20,6 +36,9 @@ for doing simple http requests
 wrappedCmd := appkit.New()
 output, err := wrappedCmd.InitChain("moniker", "test-chain", "/path/to/store")
 err = appkit.ChangeNodeMode("/path/to/config.toml", "seed")
+err = wrappedCmd.ApplyGenesis(&appkit.Genesis{GovMaxSquareSize: 128, Accounts: accs})
+client, err := wrappedCmd.Client("test", "/path/to/keyring")
+resp, err := client.SubmitRandomBlob(ctx, rand.New(rand.NewSource(1)), "moniker", 1024, appkit.TxOptions{Gas: 1000000, Fees: "10000utia"})
 hash, err = appkit.GetBlockByHeight(net.Parse("127.0.0.1"), 10)
 */
 package appkit
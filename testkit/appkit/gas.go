@@ -0,0 +1,188 @@
+package appkit
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	sdkmath "cosmossdk.io/math"
+	blobtypes "github.com/celestiaorg/celestia-app/x/blob/types"
+	"github.com/testground/sdk-go/runtime"
+)
+
+const (
+	// DefaultGasPerBlobByte is the gas charged by the blob module per byte of the shares
+	// taken by a blob, so the padding of its last share is charged as well
+	DefaultGasPerBlobByte = 8
+	// DefaultPFBGasOverhead covers the signature verification and the storage of a PFB tx
+	DefaultPFBGasOverhead = 80000
+	// DefaultGasMultiplier is applied on top of the simulated gas to absorb state changes
+	// between the simulation and the execution of the tx
+	DefaultGasMultiplier = 1.2
+)
+
+// GasRequest describes the tx which gas has to be estimated
+type GasRequest struct {
+	BlobSizes []int
+	// Simulate returns the gas used by the tx when executed against the latest state.
+	// It is nil when the submitter of the tx can't simulate it (e.g. a celestia-node)
+	Simulate func(ctx context.Context) (uint64, error)
+}
+
+// GasStrategy decides the gas limit of a tx
+type GasStrategy interface {
+	Gas(ctx context.Context, req GasRequest) (uint64, error)
+}
+
+// FeeStrategy decides the fee paid in utia by a tx with the given gas limit
+type FeeStrategy interface {
+	Fee(gas uint64) sdkmath.Int
+}
+
+// FixedGas always uses the same gas limit
+type FixedGas uint64
+
+func (g FixedGas) Gas(context.Context, GasRequest) (uint64, error) {
+	return uint64(g), nil
+}
+
+// SimulatedGas simulates the tx and multiplies the gas used by Multiplier.
+// Fallback is used when the tx can't be simulated
+type SimulatedGas struct {
+	Multiplier float64
+	Fallback   GasStrategy
+}
+
+func (g SimulatedGas) Gas(ctx context.Context, req GasRequest) (uint64, error) {
+	if req.Simulate == nil {
+		if g.Fallback == nil {
+			return 0, fmt.Errorf("tx can't be simulated and no fallback gas strategy is set")
+		}
+		return g.Fallback.Gas(ctx, req)
+	}
+
+	used, err := req.Simulate(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("simulating tx: %w", err)
+	}
+	return uint64(math.Ceil(float64(used) * g.Multiplier)), nil
+}
+
+// BlobGas estimates the gas from the sizes of the blobs paid by the tx, like the blob
+// module charges it: GasPerByte for every byte of the shares taken by the blobs, plus
+// the fixed Overhead of the PFB
+type BlobGas struct {
+	GasPerByte uint64
+	Overhead   uint64
+}
+
+func (g BlobGas) Gas(_ context.Context, req GasRequest) (uint64, error) {
+	sizes := make([]uint32, len(req.BlobSizes))
+	for i, size := range req.BlobSizes {
+		if size < 0 || uint64(size) > math.MaxUint32 {
+			return 0, fmt.Errorf("invalid blob size %d", size)
+		}
+		sizes[i] = uint32(size)
+	}
+	if g.GasPerByte > math.MaxUint32 {
+		return 0, fmt.Errorf("gas per byte %d is too high", g.GasPerByte)
+	}
+	return g.Overhead + blobtypes.GasToConsume(sizes, uint32(g.GasPerByte)), nil
+}
+
+// FixedFee always pays the same fee
+type FixedFee sdkmath.Int
+
+func (f FixedFee) Fee(uint64) sdkmath.Int {
+	return sdkmath.Int(f)
+}
+
+// GasPriceFee pays Price utia per unit of gas
+type GasPriceFee struct {
+	Price float64
+}
+
+func (f GasPriceFee) Fee(gas uint64) sdkmath.Int {
+	return sdkmath.NewInt(int64(math.Ceil(float64(gas) * f.Price)))
+}
+
+// TxStrategy combines the gas and the fee strategies of a tx
+type TxStrategy struct {
+	Gas GasStrategy
+	Fee FeeStrategy
+}
+
+// FixedStrategy is the strategy of a hard-coded gas limit and fee
+func FixedStrategy(gas uint64, fee int64) TxStrategy {
+	return TxStrategy{
+		Gas: FixedGas(gas),
+		Fee: FixedFee(sdkmath.NewInt(fee)),
+	}
+}
+
+// Estimate returns the gas limit and the fee of the described tx
+func (s TxStrategy) Estimate(ctx context.Context, req GasRequest) (uint64, sdkmath.Int, error) {
+	gas, err := s.Gas.Gas(ctx, req)
+	if err != nil {
+		return 0, sdkmath.Int{}, err
+	}
+	return gas, s.Fee.Fee(gas), nil
+}
+
+// TxStrategyFromParams builds the strategy selected by the composition params:
+//
+//   - gas-strategy: "fixed" (uses gas-limit), "simulate" (uses gas-multiplier) or "blob" (uses gas-per-byte)
+//   - fee-strategy: "fixed" (uses fee) or "gas-price" (uses gas-price)
+//
+// The defaults are used for the strategies and values which are not set
+func TxStrategyFromParams(runenv *runtime.RunEnv, defaults TxStrategy) (TxStrategy, error) {
+	strategy := defaults
+
+	gas := defaults.Gas
+	if runenv.IsParamSet("gas-limit") {
+		gas = FixedGas(runenv.IntParam("gas-limit"))
+	}
+
+	blobGas := BlobGas{GasPerByte: DefaultGasPerBlobByte, Overhead: DefaultPFBGasOverhead}
+	if runenv.IsParamSet("gas-per-byte") {
+		blobGas.GasPerByte = uint64(runenv.IntParam("gas-per-byte"))
+	}
+
+	switch name := stringParam(runenv, "gas-strategy", "fixed"); name {
+	case "fixed":
+		strategy.Gas = gas
+	case "simulate":
+		multiplier := DefaultGasMultiplier
+		if runenv.IsParamSet("gas-multiplier") {
+			multiplier = runenv.FloatParam("gas-multiplier")
+		}
+		strategy.Gas = SimulatedGas{Multiplier: multiplier, Fallback: blobGas}
+	case "blob":
+		strategy.Gas = blobGas
+	default:
+		return TxStrategy{}, fmt.Errorf("unknown gas-strategy %q, supported are fixed, simulate and blob", name)
+	}
+
+	switch name := stringParam(runenv, "fee-strategy", "fixed"); name {
+	case "fixed":
+		if runenv.IsParamSet("fee") {
+			strategy.Fee = FixedFee(sdkmath.NewInt(int64(runenv.IntParam("fee"))))
+		}
+	case "gas-price":
+		if !runenv.IsParamSet("gas-price") {
+			return TxStrategy{}, fmt.Errorf("fee-strategy gas-price requires the gas-price param")
+		}
+		strategy.Fee = GasPriceFee{Price: runenv.FloatParam("gas-price")}
+	default:
+		return TxStrategy{}, fmt.Errorf("unknown fee-strategy %q, supported are fixed and gas-price", name)
+	}
+
+	return strategy, nil
+}
+
+func stringParam(runenv *runtime.RunEnv, name, def string) string {
+	if runenv.IsParamSet(name) {
+		return runenv.StringParam(name)
+	}
+	return def
+}
@@ -0,0 +1,240 @@
+package appkit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/celestiaorg/test-infra/testkit/randkit"
+)
+
+// Genesis describes the changes applied to the genesis.json created by InitChain.
+// The zero values keep the defaults of celestia-app
+type Genesis struct {
+	// MaxBlockBytes is consensus_params.block.max_bytes
+	MaxBlockBytes int64
+	// TimeIota is consensus_params.block.time_iota_ms
+	TimeIota time.Duration
+	// GovMaxSquareSize is the max square size of the blob module, bounded by the
+	// square size upper bound of the app
+	GovMaxSquareSize uint64
+	// UnbondingTime is the unbonding time of the staking module
+	UnbondingTime time.Duration
+	// Accounts are funded in the genesis, e.g. the validators and the DA nodes
+	Accounts []GenesisAccount
+}
+
+// GenesisAccount is an account with a balance of Amount utia at genesis
+type GenesisAccount struct {
+	Address string
+	Amount  int64
+}
+
+func (g *Genesis) Validate() error {
+	var errs []error
+	if g.MaxBlockBytes < 0 {
+		errs = append(errs, fmt.Errorf("max block bytes must be >= 0, got %d", g.MaxBlockBytes))
+	}
+	if g.TimeIota < 0 || g.TimeIota%time.Millisecond != 0 {
+		errs = append(errs, fmt.Errorf("time iota must be a whole amount of milliseconds, got %s", g.TimeIota))
+	}
+	if g.GovMaxSquareSize&(g.GovMaxSquareSize-1) != 0 {
+		errs = append(errs, fmt.Errorf("max square size must be a power of 2, got %d", g.GovMaxSquareSize))
+	}
+	if g.UnbondingTime < 0 || g.UnbondingTime%time.Second != 0 {
+		errs = append(errs, fmt.Errorf("unbonding time must be a whole amount of seconds, got %s", g.UnbondingTime))
+	}
+	for _, acc := range g.Accounts {
+		if acc.Address == "" || acc.Amount <= 0 {
+			errs = append(errs, fmt.Errorf("genesis account %q must have a positive amount, got %d", acc.Address, acc.Amount))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ApplyGenesis changes the params of the genesis.json in the home of the validator and
+// adds the accounts of the Genesis with add-genesis-account
+func (ak *AppKit) ApplyGenesis(g *Genesis) error {
+	err := g.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = PatchGenesis(filepath.Join(ak.Home, "config", "genesis.json"), g)
+	if err != nil {
+		return err
+	}
+
+	for _, acc := range g.Accounts {
+		_, err = ak.AddGenAccount(acc.Address, fmt.Sprintf("%dutia", acc.Amount))
+		if err != nil {
+			return fmt.Errorf("adding genesis account %s: %w", acc.Address, err)
+		}
+	}
+	return nil
+}
+
+// PatchGenesis writes the params of the Genesis into the genesis.json at path.
+// The accounts are not added, as their balances also change the supply of the bank
+func PatchGenesis(path string, g *Genesis) error {
+	if g.MaxBlockBytes == 0 && g.TimeIota == 0 && g.GovMaxSquareSize == 0 && g.UnbondingTime == 0 {
+		return nil
+	}
+
+	bt, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var doc map[string]interface{}
+	err = json.Unmarshal(bt, &doc)
+	if err != nil {
+		return fmt.Errorf("decoding %s: %w", path, err)
+	}
+
+	// int64 and uint64 fields are encoded as strings by tendermint and the cosmos-sdk
+	var errs []error
+	if g.MaxBlockBytes != 0 {
+		errs = append(errs, setPath(doc, strconv.FormatInt(g.MaxBlockBytes, 10),
+			"consensus_params", "block", "max_bytes"))
+	}
+	if g.TimeIota != 0 {
+		errs = append(errs, setPath(doc, strconv.FormatInt(g.TimeIota.Milliseconds(), 10),
+			"consensus_params", "block", "time_iota_ms"))
+	}
+	if g.GovMaxSquareSize != 0 {
+		errs = append(errs, setPath(doc, strconv.FormatUint(g.GovMaxSquareSize, 10),
+			"app_state", "blob", "params", "gov_max_square_size"))
+	}
+	if g.UnbondingTime != 0 {
+		errs = append(errs, setPath(doc, fmt.Sprintf("%ds", int64(g.UnbondingTime.Seconds())),
+			"app_state", "staking", "params", "unbonding_time"))
+	}
+	err = errors.Join(errs...)
+	if err != nil {
+		return fmt.Errorf("patching %s: %w", path, err)
+	}
+
+	bt, err = json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bt, 0777)
+}
+
+// setPath sets the value of an existing field of the JSON document
+func setPath(doc map[string]interface{}, value interface{}, path ...string) error {
+	obj := doc
+	for i, key := range path[:len(path)-1] {
+		next, ok := obj[key].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("genesis has no %v object", path[:i+1])
+		}
+		obj = next
+	}
+
+	last := path[len(path)-1]
+	if _, ok := obj[last]; !ok {
+		return fmt.Errorf("genesis has no %v field", path)
+	}
+	obj[last] = value
+	return nil
+}
+
+// SetMinGasPrice sets the minimum gas price in utia the validator accepts the txs at.
+// celestia-app v1 has no global min gas price param, so it lives in the app.toml of every validator
+func SetMinGasPrice(home string, price float64) error {
+	return updateConfig(
+		filepath.Join(home, "config", "app.toml"),
+		"minimum-gas-prices",
+		fmt.Sprintf("%sutia", strconv.FormatFloat(price, 'f', -1, 64)),
+	)
+}
+
+// StakeDistribution decides how much every validator self-delegates
+type StakeDistribution string
+
+const (
+	// EqualStake gives Base to every validator
+	EqualStake StakeDistribution = "equal"
+	// LinearStake gives Base*(i+1) to the i-th validator
+	LinearStake StakeDistribution = "linear"
+	// GeometricStake gives Base*Factor^i to the i-th validator
+	GeometricStake StakeDistribution = "geometric"
+	// WhaleStake gives Base*Factor to the first validator and Base to the others
+	WhaleStake StakeDistribution = "whale"
+)
+
+// Stakes are the self-delegations in utia of the validators, numbered from 0
+type Stakes struct {
+	Distribution StakeDistribution
+	Base         int64
+	Factor       float64
+}
+
+func (s Stakes) Validate() error {
+	if s.Base <= 0 {
+		return fmt.Errorf("base stake must be > 0, got %d", s.Base)
+	}
+	switch s.Distribution {
+	case EqualStake, LinearStake:
+		return nil
+	case GeometricStake, WhaleStake:
+		if s.Factor < 1 {
+			return fmt.Errorf("stake factor must be >= 1, got %v", s.Factor)
+		}
+		return nil
+	default:
+		return fmt.Errorf(
+			"unknown stake distribution %q, supported are %s, %s, %s and %s",
+			s.Distribution, EqualStake, LinearStake, GeometricStake, WhaleStake,
+		)
+	}
+}
+
+// Of returns the stake of the i-th validator
+func (s Stakes) Of(i int) (int64, error) {
+	err := s.Validate()
+	if err != nil {
+		return 0, err
+	}
+
+	stake := float64(s.Base)
+	switch s.Distribution {
+	case LinearStake:
+		stake *= float64(i + 1)
+	case GeometricStake:
+		stake *= math.Pow(s.Factor, float64(i))
+	case WhaleStake:
+		if i == 0 {
+			stake *= s.Factor
+		}
+	}
+	if stake >= math.MaxInt64 {
+		return 0, fmt.Errorf("stake of validator %d overflows: %v", i, stake)
+	}
+	return int64(stake), nil
+}
+
+// PrefundedKey returns the key of the account of the instance with the given global
+// sequence number, derived from the seed of the run. Validators compute the addresses
+// of the DA nodes from it, so they can fund them in the genesis before the nodes exist
+func PrefundedKey(seed, globalSeq int64) *secp256k1.PrivKey {
+	secret := make([]byte, 32)
+	_, _ = randkit.New(seed, globalSeq, "prefunded-key").Read(secret)
+	return secp256k1.GenPrivKeyFromSecret(secret)
+}
+
+// PrefundedAddress returns the bech32 address of the PrefundedKey
+func PrefundedAddress(seed, globalSeq int64) (string, error) {
+	return sdk.Bech32ifyAddressBytes(app.Bech32PrefixAccAddr, PrefundedKey(seed, globalSeq).PubKey().Address())
+}
@@ -0,0 +1,44 @@
+package appkit
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/celestiaorg/celestia-app/app"
+	appcmd "github.com/celestiaorg/celestia-app/cmd/celestia-appd/cmd"
+	svrcmd "github.com/cosmos/cosmos-sdk/server/cmd"
+)
+
+// processEnv is set in the environment of the child processes running celestia-appd
+const processEnv = "APPKIT_PROCESS"
+
+// init runs celestia-appd with the arguments of the process instead of the test case
+// when the binary of the instance is started by command
+func init() {
+	if os.Getenv(processEnv) == "" {
+		return
+	}
+
+	err := svrcmd.Execute(appcmd.NewRootCmd(), appcmd.EnvPrefix, app.DefaultNodeHome)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// command returns the command running celestia-appd with the args. The child process is
+// the binary of this instance, which runs celestia-appd instead of the test case, so
+// celestia-appd doesn't have to be installed next to the test plan
+func command(args ...string) (*exec.Cmd, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(exe, args...)
+	cmd.Env = append(os.Environ(), processEnv+"=1")
+	setProcessAttrs(cmd)
+	return cmd, nil
+}
@@ -0,0 +1,11 @@
+package appkit
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessAttrs kills the child process when the instance exits without waiting for it
+func setProcessAttrs(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Pdeathsig: syscall.SIGKILL}
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package appkit
+
+import "os/exec"
+
+// setProcessAttrs is a no-op, the child process may outlive an instance exiting without waiting for it
+func setProcessAttrs(*exec.Cmd) {}
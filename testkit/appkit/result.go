@@ -0,0 +1,58 @@
+package appkit
+
+import (
+	"time"
+
+	"github.com/testground/sdk-go/runtime"
+)
+
+// PayForBlobResult describes a PayForBlob tx that got included in a block
+type PayForBlobResult struct {
+	TxHash    string
+	Height    int64
+	GasWanted int64
+	GasUsed   int64
+	Fee       string
+	BlobSizes []int
+	// Latency is the time between submitting the tx and getting it included
+	Latency time.Duration
+}
+
+// NewPayForBlobResult fills the result from the response of an included tx
+func NewPayForBlobResult(resp *TxResponse, blobSizes []int, latency time.Duration) *PayForBlobResult {
+	return &PayForBlobResult{
+		TxHash:    resp.TxHash,
+		Height:    resp.Height,
+		GasWanted: resp.GasWanted,
+		GasUsed:   resp.GasUsed,
+		Fee:       resp.Fee,
+		BlobSizes: blobSizes,
+		Latency:   latency,
+	}
+}
+
+// TotalBlobSize returns the sum of the sizes of all the blobs paid by the tx
+func (r *PayForBlobResult) TotalBlobSize() int {
+	var total int
+	for _, size := range r.BlobSizes {
+		total += size
+	}
+	return total
+}
+
+// Record records the result as testground metrics, so the analysis doesn't
+// need to scrape the logs of the instances
+func (r *PayForBlobResult) Record(runenv *runtime.RunEnv) {
+	runenv.R().Counter("pfb.count").Inc(1)
+	runenv.R().RecordPoint("pfb.latency_ms", float64(r.Latency.Milliseconds()))
+	runenv.R().RecordPoint("pfb.height", float64(r.Height))
+	runenv.R().RecordPoint("pfb.gas_wanted", float64(r.GasWanted))
+	runenv.R().RecordPoint("pfb.gas_used", float64(r.GasUsed))
+	runenv.R().RecordPoint("pfb.blobs", float64(len(r.BlobSizes)))
+	runenv.R().RecordPoint("pfb.blob_bytes", float64(r.TotalBlobSize()))
+
+	runenv.RecordMessage(
+		"pfb %s included at height %d after %s, gas used %d/%d, fee %s, blob sizes %v",
+		r.TxHash, r.Height, r.Latency, r.GasUsed, r.GasWanted, r.Fee, r.BlobSizes,
+	)
+}
@@ -0,0 +1,122 @@
+package assignkit
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// Strategy is the way the clients are assigned to the servers
+type Strategy string
+
+const (
+	RoundRobin Strategy = "round-robin"
+	Random     Strategy = "random"
+	Locality   Strategy = "locality"
+	FanIn      Strategy = "fan-in"
+	AllToAll   Strategy = "all-to-all"
+)
+
+// Strategies are all the supported strategies
+var Strategies = []Strategy{RoundRobin, Random, Locality, FanIn, AllToAll}
+
+// Node is a client or a server of an assignment
+type Node struct {
+	// ID is unique among the clients and among the servers, e.g. the group sequence number
+	ID int
+	// Zone is only used by the locality strategy
+	Zone string
+}
+
+// Config selects how the clients are assigned to the servers
+type Config struct {
+	Strategy Strategy
+	// Seed is only used by the random strategy
+	Seed int64
+	// FanIn is the amount of servers the clients are concentrated on by the fan-in strategy
+	FanIn int
+}
+
+func (c Config) Validate() error {
+	switch c.Strategy {
+	case RoundRobin, Random, Locality, AllToAll:
+		return nil
+	case FanIn:
+		if c.FanIn < 1 {
+			return fmt.Errorf("fan-in must be >= 1, got %d", c.FanIn)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown assignment strategy %q, supported are %v", c.Strategy, Strategies)
+	}
+}
+
+// Assign returns the sorted IDs of the servers assigned to the client
+func (c Config) Assign(client Node, servers []Node) ([]int, error) {
+	err := c.Validate()
+	if err != nil {
+		return nil, err
+	}
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("no server to assign client %d to", client.ID)
+	}
+
+	sorted := make([]Node, len(servers))
+	copy(sorted, servers)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	var assigned []Node
+	switch c.Strategy {
+	case RoundRobin:
+		assigned = []Node{roundRobin(client, sorted)}
+	case Random:
+		r := rand.New(rand.NewSource(c.Seed ^ int64(client.ID)*0x5bd1e995))
+		assigned = []Node{sorted[r.Intn(len(sorted))]}
+	case Locality:
+		var local []Node
+		for _, s := range sorted {
+			if s.Zone == client.Zone {
+				local = append(local, s)
+			}
+		}
+		if len(local) == 0 {
+			local = sorted
+		}
+		assigned = []Node{roundRobin(client, local)}
+	case FanIn:
+		if c.FanIn < len(sorted) {
+			sorted = sorted[:c.FanIn]
+		}
+		assigned = []Node{roundRobin(client, sorted)}
+	case AllToAll:
+		assigned = sorted
+	}
+
+	ids := make([]int, len(assigned))
+	for i, s := range assigned {
+		ids[i] = s.ID
+	}
+	return ids, nil
+}
+
+// Topology assigns all the clients, keyed by their ID
+func (c Config) Topology(clients, servers []Node) (map[int][]int, error) {
+	topology := make(map[int][]int, len(clients))
+	for _, client := range clients {
+		ids, err := c.Assign(client, servers)
+		if err != nil {
+			return nil, err
+		}
+		topology[client.ID] = ids
+	}
+	return topology, nil
+}
+
+// roundRobin picks the server matching the 1-based ID of the client modulo the amount of servers
+func roundRobin(client Node, servers []Node) Node {
+	i := (client.ID - 1) % len(servers)
+	if i < 0 {
+		i += len(servers)
+	}
+	return servers[i]
+}
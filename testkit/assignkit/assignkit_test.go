@@ -0,0 +1,121 @@
+package assignkit
+
+import (
+	"reflect"
+	"testing"
+)
+
+// servers are out of order, Assign sorts them by ID
+var servers = []Node{
+	{ID: 3, Zone: "zone-1"},
+	{ID: 1, Zone: "zone-1"},
+	{ID: 2, Zone: "zone-2"},
+	{ID: 4, Zone: "zone-3"},
+}
+
+var clients = []Node{
+	{ID: 1, Zone: "zone-1"},
+	{ID: 2, Zone: "zone-1"},
+	{ID: 3, Zone: "zone-2"},
+	{ID: 4, Zone: "zone-4"},
+	{ID: 5},
+}
+
+func TestTopology(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want map[int][]int
+	}{
+		{
+			name: "round-robin",
+			cfg:  Config{Strategy: RoundRobin},
+			want: map[int][]int{1: {1}, 2: {2}, 3: {3}, 4: {4}, 5: {1}},
+		},
+		{
+			// clients 4 and 5 match no zone and fall back to any server
+			name: "locality",
+			cfg:  Config{Strategy: Locality},
+			want: map[int][]int{1: {1}, 2: {3}, 3: {2}, 4: {4}, 5: {1}},
+		},
+		{
+			name: "fan-in",
+			cfg:  Config{Strategy: FanIn, FanIn: 2},
+			want: map[int][]int{1: {1}, 2: {2}, 3: {1}, 4: {2}, 5: {1}},
+		},
+		{
+			name: "fan-in to a single server",
+			cfg:  Config{Strategy: FanIn, FanIn: 1},
+			want: map[int][]int{1: {1}, 2: {1}, 3: {1}, 4: {1}, 5: {1}},
+		},
+		{
+			name: "fan-in clamped to the amount of servers",
+			cfg:  Config{Strategy: FanIn, FanIn: 10},
+			want: map[int][]int{1: {1}, 2: {2}, 3: {3}, 4: {4}, 5: {1}},
+		},
+		{
+			name: "all-to-all",
+			cfg:  Config{Strategy: AllToAll},
+			want: map[int][]int{1: {1, 2, 3, 4}, 2: {1, 2, 3, 4}, 3: {1, 2, 3, 4}, 4: {1, 2, 3, 4}, 5: {1, 2, 3, 4}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.cfg.Topology(clients, servers)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRandom(t *testing.T) {
+	many := make([]Node, 32)
+	for i := range many {
+		many[i] = Node{ID: i + 1}
+	}
+	topology := func(seed int64) map[int][]int {
+		t.Helper()
+		top, err := Config{Strategy: Random, Seed: seed}.Topology(many, servers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return top
+	}
+
+	top := topology(1)
+	for id, ids := range top {
+		if len(ids) != 1 || ids[0] < 1 || ids[0] > len(servers) {
+			t.Errorf("client %d: expected a single server, got %v", id, ids)
+		}
+	}
+	if again := topology(1); !reflect.DeepEqual(top, again) {
+		t.Errorf("the same seed gives another topology: %v and %v", top, again)
+	}
+	if other := topology(2); reflect.DeepEqual(top, other) {
+		t.Errorf("another seed gives the same topology: %v", top)
+	}
+}
+
+func TestAssignErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		servers []Node
+	}{
+		{name: "no server", cfg: Config{Strategy: RoundRobin}},
+		{name: "unknown strategy", cfg: Config{Strategy: "closest"}, servers: servers},
+		{name: "no fan-in", cfg: Config{Strategy: FanIn}, servers: servers},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.cfg.Assign(Node{ID: 1}, tt.servers)
+			if err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+/*
+Package assignkit decides which servers (e.g. bridges) every client (e.g. a full node) connects to
+
+The clients and the servers are identified by the IDs they publish to the sync
+service. An assignment only depends on the Config, the client and the set of servers,
+so every instance computes the same topology and a run can be reproduced:
+
+- round-robin spreads the clients evenly, the i-th client connects to the i-th server modulo their amount
+- random connects every client to a server drawn from the Seed
+- locality connects a client to the servers of its zone, round-robin, or to any server if its zone has none
+- fan-in concentrates all the clients on the first FanIn servers, round-robin
+- all-to-all connects every client to every server
+
+	cfg := assignkit.Config{Strategy: assignkit.FanIn, FanIn: 1}
+	ids, err := cfg.Assign(assignkit.Node{ID: 3}, []assignkit.Node{{ID: 1}, {ID: 2}})
+*/
+package assignkit
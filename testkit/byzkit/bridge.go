@@ -0,0 +1,193 @@
+package byzkit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/core"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/rsmt2d"
+	"github.com/ipfs/boxo/blockstore"
+	coretypes "github.com/tendermint/tendermint/types"
+	"github.com/testground/sdk-go/runtime"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/test-infra/testkit/waitkit"
+)
+
+// withholdConfig bounds the wait for the square to be stored before it's dropped
+var withholdConfig = waitkit.Config{
+	Timeout:     time.Minute,
+	Interval:    10 * time.Millisecond,
+	MaxInterval: 100 * time.Millisecond,
+	Factor:      1.5,
+}
+
+// Bridge turns a bridge node byzantine. It replaces the header constructor of the bridge,
+// which is handed the extended square of every block before the bridge stores it,
+// so the attacks apply to everything the bridge serves over shrex and bitswap
+type Bridge struct {
+	runenv *runtime.RunEnv
+	cfg    Config
+	store  *eds.Store
+	// bs serves the blocks to bitswap when only a fraction of the squares is withheld
+	bs *withholdingBlockstore
+
+	m       sync.Mutex
+	squares []Square
+}
+
+// NewBridge returns the byzantine bridge of the config, see Options
+func NewBridge(runenv *runtime.RunEnv, cfg Config) (*Bridge, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return &Bridge{runenv: runenv, cfg: cfg}, nil
+}
+
+// Options are the options of the bridge node to build it byzantine
+func (b *Bridge) Options() fx.Option {
+	opts := []fx.Option{
+		core.WithHeaderConstructFn(b.construct),
+		fx.Invoke(func(store *eds.Store) {
+			b.store = store
+		}),
+	}
+	if b.partial() {
+		opts = append(opts, fx.Decorate(func(bs blockstore.Blockstore) blockstore.Blockstore {
+			b.bs = newWithholdingBlockstore(bs)
+			return b.bs
+		}))
+	}
+	return fx.Options(opts...)
+}
+
+// Attacked returns the heights attacked so far. The attacked heights with
+// an empty block are not attacked, as there is no share to misbehave with
+func (b *Bridge) Attacked() []uint64 {
+	squares := b.Squares()
+	heights := make([]uint64, len(squares))
+	for i, sq := range squares {
+		heights[i] = sq.Height
+	}
+	return heights
+}
+
+// Squares returns the squares attacked so far
+func (b *Bridge) Squares() []Square {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return append([]Square(nil), b.squares...)
+}
+
+// partial reports whether only a fraction of the squares is withheld
+func (b *Bridge) partial() bool {
+	return b.cfg.Attack == Withhold && b.cfg.Fraction < 1
+}
+
+func (b *Bridge) construct(
+	ctx context.Context,
+	blk *coretypes.Block,
+	comm *coretypes.Commit,
+	vals *coretypes.ValidatorSet,
+	square *rsmt2d.ExtendedDataSquare,
+) (*header.ExtendedHeader, error) {
+	height := uint64(blk.Height)
+	if square == nil || !b.cfg.Attacks(height) {
+		return header.MakeExtendedHeader(ctx, blk, comm, vals, square)
+	}
+
+	width := square.Width()
+	eh, withheld, err := b.attack(ctx, blk, comm, vals, square)
+	if err != nil {
+		return nil, fmt.Errorf("byzantine bridge attacking height %d: %w", height, err)
+	}
+
+	b.m.Lock()
+	b.squares = append(b.squares, Square{Height: height, Width: width, Withheld: withheld})
+	b.m.Unlock()
+	b.runenv.RecordMessage("Byzantine bridge attacked height %d with %s", height, b.cfg.Attack)
+	return eh, nil
+}
+
+// attack constructs the header of the attacked block and swaps the square the bridge stores.
+// It returns the amount of shares withheld from the light nodes as well
+func (b *Bridge) attack(
+	ctx context.Context,
+	blk *coretypes.Block,
+	comm *coretypes.Commit,
+	vals *coretypes.ValidatorSet,
+	square *rsmt2d.ExtendedDataSquare,
+) (*header.ExtendedHeader, int, error) {
+	eh, err := header.MakeExtendedHeader(ctx, blk, comm, vals, square)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	odsWidth := square.Width() / 2
+	var flipped func(row, col uint) bool
+	switch b.cfg.Attack {
+	case Withhold:
+		if !b.partial() {
+			go b.withhold(eh.DAH.Hash())
+			return eh, int(square.Width() * square.Width()), nil
+		}
+
+		leaves, withheld, err := withheldLeaves(square, b.cfg.Fraction, b.cfg.Seed, uint64(blk.Height))
+		if err != nil {
+			return nil, 0, err
+		}
+		b.bs.withhold(leaves)
+		return eh, withheld, nil
+	case CorruptShares:
+		flipped = func(row, col uint) bool { return row < odsWidth && col < odsWidth }
+	case CorruptProofs:
+		flipped = func(row, col uint) bool { return row >= odsWidth || col >= odsWidth }
+	}
+
+	corrupted, err := corrupt(square, flipped)
+	if err != nil {
+		return nil, 0, err
+	}
+	*square = *corrupted
+	return eh, 0, nil
+}
+
+// withhold drops the square from the store once the bridge stored it after the construction
+func (b *Bridge) withhold(hash share.DataHash) {
+	ctx := context.Background()
+	err := waitkit.Until(ctx, withholdConfig, func(ctx context.Context) (bool, error) {
+		return b.store.Has(ctx, hash)
+	})
+	if err == nil {
+		err = b.store.Remove(ctx, hash)
+	}
+	if err != nil {
+		b.runenv.RecordMessage("Byzantine bridge failed to withhold the square %X: %s", hash, err)
+	}
+}
+
+// corrupt returns a copy of the square with the data of the selected shares flipped.
+// The namespaces are kept, so the trees of the copy are still ordered by namespace
+func corrupt(square *rsmt2d.ExtendedDataSquare, flipped func(row, col uint) bool) (*rsmt2d.ExtendedDataSquare, error) {
+	width := square.Width()
+	shares := make([][]byte, 0, width*width)
+	for row := uint(0); row < width; row++ {
+		for col := uint(0); col < width; col++ {
+			shr := append([]byte(nil), square.GetCell(row, col)...)
+			if flipped(row, col) {
+				for i := share.NamespaceSize; i < len(shr); i++ {
+					shr[i] ^= 0xFF
+				}
+			}
+			shares = append(shares, shr)
+		}
+	}
+	return rsmt2d.ImportExtendedDataSquare(shares, share.DefaultRSMT2DCodec(), wrapper.NewConstructor(uint64(width/2)))
+}
@@ -0,0 +1,115 @@
+package byzkit
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Attack is the misbehaviour of a byzantine bridge at the attacked heights
+type Attack string
+
+const (
+	// Withhold drops the square of the height from the store of the bridge once it's stored,
+	// so neither shrex nor bitswap serve any of its shares. With a Fraction below 1, only
+	// that fraction of the shares is withheld from bitswap, which the light nodes sample from
+	Withhold Attack = "withhold"
+	// CorruptShares stores the square with every original share flipped under the data root
+	// of the honest header, so the bridge serves shares which don't match the header
+	CorruptShares Attack = "corrupt-shares"
+	// CorruptProofs stores the square with every parity share flipped. The original shares
+	// served over shrex are right, but none of the NMT proofs served over bitswap match the roots
+	CorruptProofs Attack = "corrupt-proofs"
+)
+
+// Attacks are all the supported attacks. A bad encoding can't be produced by a bridge,
+// as the header committing to it has to be signed by the validators
+var Attacks = []Attack{Withhold, CorruptShares, CorruptProofs}
+
+// ParseAttack parses the name of a supported attack
+func ParseAttack(s string) (Attack, error) {
+	for _, a := range Attacks {
+		if string(a) == s {
+			return a, nil
+		}
+	}
+	return "", fmt.Errorf("unknown attack %q, supported are %v", s, Attacks)
+}
+
+// Config is the attack of a byzantine bridge and the heights it applies to
+type Config struct {
+	Attack Attack
+	// Heights are the sorted attacked heights
+	Heights []uint64
+	// Fraction is the fraction of the extended square withheld by the Withhold attack.
+	// Only the shares depending on the data of the square are withheld, see Square.Withheld
+	Fraction float64
+	// Seed selects the withheld shares, the same on all the bridges with the same seed
+	Seed int64
+}
+
+func (c Config) Validate() error {
+	_, err := ParseAttack(string(c.Attack))
+	if err != nil {
+		return err
+	}
+	if c.Attack == Withhold && (c.Fraction <= 0 || c.Fraction > 1) {
+		return fmt.Errorf("withheld fraction must be in (0, 1], got %v", c.Fraction)
+	}
+	if len(c.Heights) == 0 {
+		return fmt.Errorf("no attacked height")
+	}
+	for _, h := range c.Heights {
+		// the bridge trusts the hash of the first block
+		if h < 2 {
+			return fmt.Errorf("attacked heights must be >= 2, got %d", h)
+		}
+	}
+	return nil
+}
+
+// Attacks reports whether the height is attacked
+func (c Config) Attacks(height uint64) bool {
+	i := sort.Search(len(c.Heights), func(i int) bool { return c.Heights[i] >= height })
+	return i < len(c.Heights) && c.Heights[i] == height
+}
+
+// ParseHeights parses the comma separated heights and inclusive ranges of heights,
+// e.g. "10,12-14" are the heights 10, 12, 13 and 14. The heights are returned sorted
+func ParseHeights(s string) ([]uint64, error) {
+	seen := make(map[uint64]bool)
+	var heights []uint64
+	for _, raw := range strings.Split(s, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		from, to, isRange := strings.Cut(raw, "-")
+		first, err := strconv.ParseUint(from, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid height %q", from)
+		}
+		last := first
+		if isRange {
+			last, err = strconv.ParseUint(to, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid height %q", to)
+			}
+			if last < first {
+				return nil, fmt.Errorf("invalid range %q", raw)
+			}
+		}
+
+		for h := first; h <= last; h++ {
+			if !seen[h] {
+				seen[h] = true
+				heights = append(heights, h)
+			}
+		}
+	}
+
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights, nil
+}
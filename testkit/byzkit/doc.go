@@ -0,0 +1,28 @@
+/*
+Package byzkit turns bridge nodes byzantine, to check the full and light nodes detect them
+
+A byzantine Bridge replaces the header constructor of the bridge node, which gets the
+extended square of every block before the bridge stores and serves it. At the heights
+of the Config, the non-empty squares are attacked with one of the Attacks:
+
+- withhold: the square is dropped from the store, or the Fraction of its shares is withheld from bitswap
+- corrupt-shares: the original shares served don't match the data root of the header
+- corrupt-proofs: the parity shares, and so the NMT proofs of every row, don't match the roots
+
+The full and light nodes sampling from the bridge are expected to fail the withheld and the
+corrupted heights. The full nodes get the original shares over shrex though, so they recover
+the squares with corrupted proofs and then serve them to the light nodes.
+
+The shares withheld from bitswap are selected by the Seed and the height, so all the bridges
+with the same Seed withhold the same ones. Bitswap serves the shares by content, so only the
+shares depending on the data of the square are withheld, not the padding found in every square. Square.DetectionProbability is the probability
+a light node detects the withholding of an attacked Square with its samples.
+
+	heights, err := byzkit.ParseHeights("10,12-14")
+	cfg := byzkit.Config{Attack: byzkit.Withhold, Heights: heights, Fraction: 0.25, Seed: randkit.Seed(runenv)}
+	byz, err := byzkit.NewBridge(runenv, cfg)
+	nd, err := common.BuildBridge(ctx, runenv, initCtx, byz.Options())
+	squares := byz.Squares()
+	p := squares[0].DetectionProbability(16)
+*/
+package byzkit
@@ -0,0 +1,214 @@
+package byzkit
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"sync"
+
+	appns "github.com/celestiaorg/celestia-app/pkg/namespace"
+	appshares "github.com/celestiaorg/celestia-app/pkg/shares"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/rsmt2d"
+	"github.com/ipfs/boxo/blockstore"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	format "github.com/ipfs/go-ipld-format"
+
+	"github.com/celestiaorg/test-infra/testkit/randkit"
+)
+
+// Square is an attacked square of a byzantine bridge
+type Square struct {
+	Height uint64
+	// Width of the extended square
+	Width uint
+	// Withheld is the amount of shares withheld from the light nodes, which is below the
+	// fraction of the Config when the square has too few shares depending on its data
+	Withheld int
+}
+
+// DetectionProbability is the probability a light node sampling the square detects the
+// withholding, i.e. at least one of its distinct samples is withheld
+func (s Square) DetectionProbability(samples int) float64 {
+	total := int(s.Width * s.Width)
+	if samples > total {
+		samples = total
+	}
+
+	// the probability all the samples are available, drawn without replacement
+	available := 1.0
+	for i := 0; i < samples; i++ {
+		if total-s.Withheld-i <= 0 {
+			return 1
+		}
+		available *= float64(total-s.Withheld-i) / float64(total-i)
+	}
+	return 1 - available
+}
+
+// withholdingBlockstore doesn't serve the withheld leaves of the NMTs, so the samples of
+// the withheld shares fail, while the rest of the square is served as usual
+type withholdingBlockstore struct {
+	blockstore.Blockstore
+
+	m        sync.RWMutex
+	withheld map[cid.Cid]bool
+}
+
+func newWithholdingBlockstore(bs blockstore.Blockstore) *withholdingBlockstore {
+	return &withholdingBlockstore{Blockstore: bs, withheld: make(map[cid.Cid]bool)}
+}
+
+// withhold withholds the leaves until the end of the run. The withheld leaves depend on the data
+// of their square, so none of the other squares the bridge serves has them
+func (bs *withholdingBlockstore) withhold(cids []cid.Cid) {
+	bs.m.Lock()
+	defer bs.m.Unlock()
+	for _, c := range cids {
+		bs.withheld[c] = true
+	}
+}
+
+func (bs *withholdingBlockstore) isWithheld(c cid.Cid) bool {
+	bs.m.RLock()
+	defer bs.m.RUnlock()
+	return bs.withheld[c]
+}
+
+func (bs *withholdingBlockstore) Has(ctx context.Context, c cid.Cid) (bool, error) {
+	if bs.isWithheld(c) {
+		return false, nil
+	}
+	return bs.Blockstore.Has(ctx, c)
+}
+
+func (bs *withholdingBlockstore) Get(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	if bs.isWithheld(c) {
+		return nil, format.ErrNotFound{Cid: c}
+	}
+	return bs.Blockstore.Get(ctx, c)
+}
+
+func (bs *withholdingBlockstore) GetSize(ctx context.Context, c cid.Cid) (int, error) {
+	if bs.isWithheld(c) {
+		return 0, format.ErrNotFound{Cid: c}
+	}
+	return bs.Blockstore.GetSize(ctx, c)
+}
+
+// withheldLeaves returns the CIDs of the NMT leaves of the fraction of the shares of the
+// square selected by the seed and the height, and the amount of shares they withhold.
+//
+// The leaves are addressed by their content, so a leaf is withheld wherever it appears.
+// Only the shares depending on the data of the square are selected, see unique, as the
+// padding shares and the parity of padding are the same in the other squares
+func withheldLeaves(square *rsmt2d.ExtendedDataSquare, fraction float64, seed int64, height uint64) ([]cid.Cid, int, error) {
+	width := square.Width()
+	total := int(width * width)
+	amount := int(math.Round(fraction * float64(total)))
+
+	isUnique, err := unique(square)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// all the bridges withhold the same shares, so the global sequence number is not used
+	rnd := randkit.New(seed, 0, fmt.Sprintf("withhold-%d", height))
+	selected := make(map[int]bool, amount)
+	for _, i := range rnd.Perm(total) {
+		if len(selected) == amount {
+			break
+		}
+		if isUnique(uint(i)/width, uint(i)%width) {
+			selected[i] = true
+		}
+	}
+
+	var (
+		cids     []cid.Cid
+		withheld = make(map[cid.Cid]bool, amount)
+		leaves   = make([]cid.Cid, total)
+	)
+	for i := range leaves {
+		c, err := leafCID(square, uint(i)/width, uint(i)%width)
+		if err != nil {
+			return nil, 0, err
+		}
+		leaves[i] = c
+		if selected[i] && !withheld[c] {
+			withheld[c] = true
+			cids = append(cids, c)
+		}
+	}
+
+	// the shares actually unavailable, including the copies of the withheld ones
+	shares := 0
+	for _, c := range leaves {
+		if withheld[c] {
+			shares++
+		}
+	}
+	return cids, shares, nil
+}
+
+// unique reports which shares of the square depend on its data, i.e. on an original share
+// which is not padding. An original share depends on itself, a parity share of the top right
+// quadrant on its row, one of the bottom left quadrant on its column and the bottom right
+// quadrant on the whole original square
+func unique(square *rsmt2d.ExtendedDataSquare) (func(row, col uint) bool, error) {
+	odsWidth := square.Width() / 2
+	data := make([][]bool, odsWidth)
+	rows := make([]bool, odsWidth)
+	cols := make([]bool, odsWidth)
+	hasData := false
+	for row := uint(0); row < odsWidth; row++ {
+		data[row] = make([]bool, odsWidth)
+		for col := uint(0); col < odsWidth; col++ {
+			shr, err := appshares.NewShare(square.GetCell(row, col))
+			if err != nil {
+				return nil, err
+			}
+			padding, err := shr.IsPadding()
+			if err != nil {
+				return nil, err
+			}
+			if !padding {
+				data[row][col], rows[row], cols[col], hasData = true, true, true, true
+			}
+		}
+	}
+
+	return func(row, col uint) bool {
+		switch {
+		case row < odsWidth && col < odsWidth:
+			return data[row][col]
+		case row < odsWidth:
+			return rows[row]
+		case col < odsWidth:
+			return cols[col]
+		default:
+			return hasData
+		}
+	}, nil
+}
+
+// leafCID is the CID of the NMT leaf of the share, which is the same in the row and
+// the column trees. The shares out of the original square are in the parity namespace
+func leafCID(square *rsmt2d.ExtendedDataSquare, row, col uint) (cid.Cid, error) {
+	shr := square.GetCell(row, col)
+	ns := shr[:share.NamespaceSize]
+	if odsWidth := square.Width() / 2; row >= odsWidth || col >= odsWidth {
+		ns = appns.ParitySharesNamespace.Bytes()
+	}
+
+	hasher := nmt.NewNmtHasher(sha256.New(), share.NamespaceSize, true)
+	leaf, err := hasher.HashLeaf(append(append([]byte(nil), ns...), shr...))
+	if err != nil {
+		return cid.Undef, err
+	}
+	return ipld.CidFromNamespacedSha256(leaf)
+}
@@ -0,0 +1,169 @@
+package daskit
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/das"
+	"github.com/testground/sdk-go/runtime"
+)
+
+// StatsFn returns the sampling stats of the DASer of a node, e.g. nd.DASer.SamplingStats
+type StatsFn func(context.Context) (das.SamplingStats, error)
+
+// Summary is the progress of the DASer of a node over the collection
+type Summary struct {
+	Duration time.Duration
+	// FirstSampled and LastSampled are the sampled chain heads of the first and the last polls
+	FirstSampled uint64
+	LastSampled  uint64
+	HeadHeight   uint64
+	// CatchUpRate is the average amount of heights sampled per second and PeakRate the highest between two polls
+	CatchUpRate float64
+	PeakRate    float64
+	// CatchUpTime is the time until the DASer caught up with the head, 0 if it never did
+	CatchUpTime    time.Duration
+	FailedHeights  []uint64
+	MaxConcurrency int
+	Polls          int
+	// Errors are the polls failed, e.g. while a crashed node is restarting
+	Errors int
+}
+
+// Collector polls the sampling stats of a node at every interval and records them as metrics
+type Collector struct {
+	runenv   *runtime.RunEnv
+	stats    StatsFn
+	interval time.Duration
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	m       sync.Mutex
+	start   time.Time
+	last    time.Time
+	summary Summary
+	failed  map[uint64]bool
+}
+
+// NewCollector returns the collector of the stats polled at every interval, see Start
+func NewCollector(runenv *runtime.RunEnv, stats StatsFn, interval time.Duration) *Collector {
+	return &Collector{
+		runenv:   runenv,
+		stats:    stats,
+		interval: interval,
+		failed:   make(map[uint64]bool),
+	}
+}
+
+// Start polls the stats in the background until Stop or the end of the context
+func (c *Collector) Start(ctx context.Context) {
+	ctx, c.cancel = context.WithCancel(ctx)
+	c.done = make(chan struct{})
+	c.start = time.Now()
+
+	go func() {
+		defer close(c.done)
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		c.poll(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.poll(ctx)
+			}
+		}
+	}()
+}
+
+// Stop stops the polling, polls the stats a last time and records the summary.
+// It has to be called before the node stops
+func (c *Collector) Stop(ctx context.Context) *Summary {
+	c.cancel()
+	<-c.done
+	c.poll(ctx)
+
+	c.m.Lock()
+	defer c.m.Unlock()
+	s := c.summary
+	s.Duration = time.Since(c.start)
+	if s.Duration > 0 {
+		s.CatchUpRate = float64(s.LastSampled-s.FirstSampled) / s.Duration.Seconds()
+	}
+	for h := range c.failed {
+		s.FailedHeights = append(s.FailedHeights, h)
+	}
+	sort.Slice(s.FailedHeights, func(i, j int) bool { return s.FailedHeights[i] < s.FailedHeights[j] })
+
+	s.record(c.runenv)
+	return &s
+}
+
+func (c *Collector) poll(ctx context.Context) {
+	stats, err := c.stats(ctx)
+	now := time.Now()
+
+	c.m.Lock()
+	defer c.m.Unlock()
+	s := &c.summary
+	if err != nil {
+		s.Errors++
+		return
+	}
+
+	if s.Polls == 0 {
+		s.FirstSampled = stats.SampledChainHead
+	} else if stats.SampledChainHead > s.LastSampled {
+		// a restarted node resumes from its checkpoint, so only the progress is counted
+		rate := float64(stats.SampledChainHead-s.LastSampled) / now.Sub(c.last).Seconds()
+		c.runenv.R().RecordPoint("das.catchup_rate", rate)
+		if rate > s.PeakRate {
+			s.PeakRate = rate
+		}
+	}
+	s.Polls++
+	if stats.SampledChainHead > s.LastSampled {
+		s.LastSampled = stats.SampledChainHead
+	}
+	if stats.NetworkHead > s.HeadHeight {
+		s.HeadHeight = stats.NetworkHead
+	}
+	if stats.Concurrency > s.MaxConcurrency {
+		s.MaxConcurrency = stats.Concurrency
+	}
+	if s.CatchUpTime == 0 && stats.CatchUpDone {
+		s.CatchUpTime = now.Sub(c.start)
+	}
+	for h := range stats.Failed {
+		c.failed[h] = true
+	}
+	c.last = now
+
+	c.runenv.R().RecordPoint("das.sampled_height", float64(stats.SampledChainHead))
+	c.runenv.R().RecordPoint("das.head_height", float64(stats.NetworkHead))
+	c.runenv.R().RecordPoint("das.catchup_head", float64(stats.CatchupHead))
+	c.runenv.R().RecordPoint("das.failed_heights", float64(len(stats.Failed)))
+	c.runenv.R().RecordPoint("das.concurrency", float64(stats.Concurrency))
+}
+
+// record records the summary as testground metrics
+func (s *Summary) record(runenv *runtime.RunEnv) {
+	runenv.R().RecordPoint("das.summary.sampled_heights", float64(s.LastSampled-s.FirstSampled))
+	runenv.R().RecordPoint("das.summary.lag", float64(s.HeadHeight)-float64(s.LastSampled))
+	runenv.R().RecordPoint("das.summary.catchup_rate", s.CatchUpRate)
+	runenv.R().RecordPoint("das.summary.peak_rate", s.PeakRate)
+	runenv.R().RecordPoint("das.summary.catchup_ms", float64(s.CatchUpTime.Milliseconds()))
+	runenv.R().RecordPoint("das.summary.failed_heights", float64(len(s.FailedHeights)))
+	runenv.R().RecordPoint("das.summary.max_concurrency", float64(s.MaxConcurrency))
+
+	runenv.RecordMessage(
+		"DASer sampled heights %d to %d of %d in %s (%.2f/s, peak %.2f/s), caught up after %s, %d failed heights, %d failed polls",
+		s.FirstSampled, s.LastSampled, s.HeadHeight, s.Duration, s.CatchUpRate, s.PeakRate,
+		s.CatchUpTime, len(s.FailedHeights), s.Errors,
+	)
+}
@@ -0,0 +1,18 @@
+/*
+Package daskit measures the progress of the DASer of the full and light nodes
+
+A Collector polls the SamplingStats of a node at every interval and records the sampled
+and the head heights, the catch-up rate, the failed heights and the concurrency as
+testground metrics. Once stopped, it records the Summary of the whole collection:
+
+- the heights sampled and the average and peak catch-up rates
+- the time until the DASer caught up with the head
+- the heights which failed sampling at least once
+
+The StatsFn is called at every poll, so it can follow a node restarted by faultkit.
+
+	c := daskit.NewCollector(runenv, nd.DASer.SamplingStats, 5*time.Second)
+	c.Start(ctx)
+	summary := c.Stop(ctx)
+*/
+package daskit
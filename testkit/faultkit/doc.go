@@ -0,0 +1,20 @@
+/*
+Package faultkit crashes and restarts nodes during a run and checks that they resume syncing
+
+A Schedule is a list of faults, each one stopping a Target once it reaches a height
+("h20/30s") or some time after the schedule started ("t5m/1m") and restarting it after
+the downtime. After every restart, the target has to be synced past the height it was
+stopped at within the Recovery deadline:
+
+- Validator stops the validator started by AppKit.StartNode, synced once it's not catching up
+- Node rebuilds a bridge, full or light node on its reopened store, synced once headers and DASer caught up
+
+The stop height, the downtime and the recovery time of every fault are recorded as metrics.
+
+	sch, err := faultkit.ParseSchedule("h20/30s,t5m/1m", 5*time.Minute)
+	target, err := faultkit.NewNode(func() (*nodebuilder.Node, nodebuilder.Store, error) { return nodekit.OpenNode(...) })
+	err = target.Node().Start(ctx)
+	results, err := faultkit.Run(ctx, runenv, target, sch)
+	nd = target.Node()
+*/
+package faultkit
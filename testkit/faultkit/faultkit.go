@@ -0,0 +1,230 @@
+package faultkit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/testground/sdk-go/runtime"
+
+	"github.com/celestiaorg/test-infra/testkit/waitkit"
+)
+
+// Target is a node which can crash and restart
+type Target interface {
+	// Name identifies the target in the messages and the metrics, e.g. "bridge"
+	Name() string
+	// Height is the latest height the target has
+	Height(ctx context.Context) (uint64, error)
+	Stop(ctx context.Context) error
+	// Start restarts the stopped target and returns once it is running
+	Start(ctx context.Context) error
+	// Synced reports whether the target caught up with the network past the given height
+	Synced(ctx context.Context, height uint64) (bool, error)
+}
+
+// Fault stops the target when its trigger is reached and restarts it after Downtime.
+// Exactly one of Height and After is set
+type Fault struct {
+	// Height the target has to reach before it is stopped
+	Height uint64
+	// After is the time since the start of the schedule the target is stopped at
+	After    time.Duration
+	Downtime time.Duration
+}
+
+func (f Fault) String() string {
+	if f.Height != 0 {
+		return fmt.Sprintf("h%d/%s", f.Height, f.Downtime)
+	}
+	return fmt.Sprintf("t%s/%s", f.After, f.Downtime)
+}
+
+// Schedule is the ordered list of the faults of a target
+type Schedule struct {
+	Faults []Fault
+	// Recovery bounds the time the target has to be synced again after every restart
+	Recovery time.Duration
+}
+
+// ParseSchedule parses the comma separated faults of a schedule, where every fault is
+// either "h<height>/<downtime>" or "t<duration>/<downtime>", e.g. "h20/30s,t5m/1m"
+// stops the target at height 20 for 30s and then 5 minutes after the schedule started for 1m.
+// An empty string is an empty schedule
+func ParseSchedule(s string, recovery time.Duration) (Schedule, error) {
+	sch := Schedule{Recovery: recovery}
+	if strings.TrimSpace(s) == "" {
+		return sch, nil
+	}
+
+	for _, raw := range strings.Split(s, ",") {
+		f, err := parseFault(strings.TrimSpace(raw))
+		if err != nil {
+			return Schedule{}, fmt.Errorf("fault %q: %w", raw, err)
+		}
+		sch.Faults = append(sch.Faults, f)
+	}
+	return sch, sch.Validate()
+}
+
+func parseFault(raw string) (Fault, error) {
+	trigger, downtime, ok := strings.Cut(raw, "/")
+	if !ok || len(trigger) < 2 {
+		return Fault{}, fmt.Errorf("expected h<height>/<downtime> or t<duration>/<downtime>")
+	}
+
+	var (
+		f   Fault
+		err error
+	)
+	f.Downtime, err = time.ParseDuration(downtime)
+	if err != nil {
+		return Fault{}, fmt.Errorf("invalid downtime: %w", err)
+	}
+
+	switch trigger[0] {
+	case 'h':
+		f.Height, err = strconv.ParseUint(trigger[1:], 10, 64)
+		if err != nil {
+			return Fault{}, fmt.Errorf("invalid height %q", trigger[1:])
+		}
+	case 't':
+		f.After, err = time.ParseDuration(trigger[1:])
+		if err != nil {
+			return Fault{}, fmt.Errorf("invalid time: %w", err)
+		}
+	default:
+		return Fault{}, fmt.Errorf("unknown trigger %q, expected h or t", trigger[:1])
+	}
+	return f, nil
+}
+
+func (s Schedule) Validate() error {
+	if len(s.Faults) > 0 && s.Recovery <= 0 {
+		return fmt.Errorf("recovery deadline must be > 0, got %s", s.Recovery)
+	}
+	for i, f := range s.Faults {
+		if (f.Height == 0) == (f.After == 0) {
+			return fmt.Errorf("fault %d must be triggered by either a height or a time", i)
+		}
+		if f.After < 0 || f.Downtime < 0 {
+			return fmt.Errorf("fault %d has a negative duration: %s", i, f)
+		}
+	}
+	return nil
+}
+
+// Result is the outcome of a fault applied to a target
+type Result struct {
+	Fault Fault
+	// StopHeight is the height of the target when it was stopped
+	StopHeight uint64
+	Downtime   time.Duration
+	// Recovery is the time from the restart until the target was synced again
+	Recovery time.Duration
+}
+
+// Record records the result as testground metrics of the target
+func (r *Result) Record(runenv *runtime.RunEnv, target string) {
+	runenv.R().Counter(fmt.Sprintf("fault.%s.count", target)).Inc(1)
+	runenv.R().RecordPoint(fmt.Sprintf("fault.%s.stop_height", target), float64(r.StopHeight))
+	runenv.R().RecordPoint(fmt.Sprintf("fault.%s.downtime_ms", target), float64(r.Downtime.Milliseconds()))
+	runenv.R().RecordPoint(fmt.Sprintf("fault.%s.recovery_ms", target), float64(r.Recovery.Milliseconds()))
+
+	runenv.RecordMessage(
+		"%s fault %s: stopped at height %d for %s, synced %s after the restart",
+		target, r.Fault, r.StopHeight, r.Downtime, r.Recovery,
+	)
+}
+
+// Run applies the faults of the schedule to the target in order. After every restart the
+// target has to be synced past the height it was stopped at within the recovery deadline,
+// otherwise the results so far are returned with an error wrapping waitkit.ErrTimeout
+func Run(ctx context.Context, runenv *runtime.RunEnv, t Target, s Schedule) ([]Result, error) {
+	err := s.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	results := make([]Result, 0, len(s.Faults))
+	for _, f := range s.Faults {
+		err = trigger(ctx, t, f, start)
+		if err != nil {
+			return results, fmt.Errorf("%s fault %s: %w", t.Name(), f, err)
+		}
+
+		res, err := apply(ctx, t, f, s.Recovery)
+		if err != nil {
+			return results, fmt.Errorf("%s fault %s: %w", t.Name(), f, err)
+		}
+		res.Record(runenv, t.Name())
+		results = append(results, *res)
+	}
+	return results, nil
+}
+
+// trigger waits until the target reaches the height or the time of the fault
+func trigger(ctx context.Context, t Target, f Fault, start time.Time) error {
+	if f.After != 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Until(start.Add(f.After))):
+			return nil
+		}
+	}
+
+	cfg := waitkit.DefaultConfig
+	if deadline, ok := ctx.Deadline(); ok {
+		cfg.Timeout = time.Until(deadline)
+	}
+	return waitkit.Until(ctx, cfg, func(ctx context.Context) (bool, error) {
+		h, err := t.Height(ctx)
+		return h >= f.Height, err
+	})
+}
+
+// apply stops the target for the downtime, restarts it and waits until it is synced
+func apply(ctx context.Context, t Target, f Fault, recovery time.Duration) (*Result, error) {
+	height, err := t.Height(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stoppedAt := time.Now()
+	err = t.Stop(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("stopping: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(f.Downtime):
+	}
+
+	err = t.Start(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("restarting: %w", err)
+	}
+	restartedAt := time.Now()
+
+	cfg := waitkit.DefaultConfig
+	cfg.Timeout = recovery
+	err = waitkit.Until(ctx, cfg, func(ctx context.Context) (bool, error) {
+		return t.Synced(ctx, height)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("resuming sync past height %d: %w", height, err)
+	}
+
+	return &Result{
+		Fault:      f,
+		StopHeight: height,
+		Downtime:   restartedAt.Sub(stoppedAt),
+		Recovery:   time.Since(restartedAt),
+	}, nil
+}
@@ -0,0 +1,138 @@
+package faultkit
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+
+	"github.com/celestiaorg/test-infra/testkit/appkit"
+	"github.com/celestiaorg/test-infra/testkit/waitkit"
+)
+
+// Validator is the CometBFT validator started by AppKit.StartNode in this instance
+type Validator struct {
+	App      *appkit.AppKit
+	LogLevel string
+	// IP the RPC of the validator is reached at
+	IP net.IP
+}
+
+func (v *Validator) Name() string {
+	return "validator"
+}
+
+func (v *Validator) Height(ctx context.Context) (uint64, error) {
+	h, err := waitkit.GetHeight(ctx, v.IP)
+	return uint64(h), err
+}
+
+func (v *Validator) Stop(ctx context.Context) error {
+	return v.App.StopNode(ctx)
+}
+
+func (v *Validator) Start(ctx context.Context) error {
+	go v.App.StartNode(v.LogLevel)
+	return waitkit.ForRPC(ctx, v.IP)
+}
+
+func (v *Validator) Synced(ctx context.Context, height uint64) (bool, error) {
+	info, err := waitkit.GetSyncInfo(ctx, v.IP)
+	if err != nil {
+		return false, err
+	}
+	return !info.CatchingUp && uint64(info.LatestBlockHeight) > height, nil
+}
+
+// Node is a bridge, full or light node. A stopped node can't be started again,
+// so Start builds a new node on top of the same store with the build func
+type Node struct {
+	m     sync.Mutex
+	nd    *nodebuilder.Node
+	store nodebuilder.Store
+	build BuildFn
+}
+
+// BuildFn builds the node and opens its store, e.g. with nodekit.OpenNode
+type BuildFn func() (*nodebuilder.Node, nodebuilder.Store, error)
+
+// NewNode builds the node with the given func, which builds it again on restarts.
+// The node is not started
+func NewNode(build BuildFn) (*Node, error) {
+	nd, store, err := build()
+	if err != nil {
+		return nil, err
+	}
+	return &Node{nd: nd, store: store, build: build}, nil
+}
+
+// Node returns the node running after the last restart
+func (n *Node) Node() *nodebuilder.Node {
+	n.m.Lock()
+	defer n.m.Unlock()
+	return n.nd
+}
+
+func (n *Node) Name() string {
+	return n.Node().Type.String()
+}
+
+func (n *Node) Height(ctx context.Context) (uint64, error) {
+	head, err := n.Node().HeaderServ.LocalHead(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(head.Height()), nil
+}
+
+// Stop stops the node and closes its store, so the next node can open it
+func (n *Node) Stop(ctx context.Context) error {
+	n.m.Lock()
+	nd, store := n.nd, n.store
+	n.m.Unlock()
+
+	err := nd.Stop(ctx)
+	if err != nil {
+		return err
+	}
+	return store.Close()
+}
+
+func (n *Node) Start(ctx context.Context) error {
+	nd, store, err := n.build()
+	if err != nil {
+		return err
+	}
+
+	err = nd.Start(ctx)
+	if err != nil {
+		return errors.Join(err, store.Close())
+	}
+
+	n.m.Lock()
+	n.nd, n.store = nd, store
+	n.m.Unlock()
+	return nil
+}
+
+// Synced reports whether the header sync is finished past the height and,
+// except for the bridges which don't sample, the DASer caught up with it
+func (n *Node) Synced(ctx context.Context, height uint64) (bool, error) {
+	nd := n.Node()
+	state, err := nd.HeaderServ.SyncState(ctx)
+	if err != nil {
+		return false, err
+	}
+	if !state.Finished() || state.Height <= height {
+		return false, nil
+	}
+
+	if nd.Type == node.Bridge {
+		return true, nil
+	}
+	err = nd.DASer.WaitCatchUp(ctx)
+	return err == nil, err
+}
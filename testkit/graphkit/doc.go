@@ -0,0 +1,23 @@
+/*
+Package graphkit generates the graphs of the persistent peers of the validators and the seeds
+
+A Config generates the same Graph on every instance, as it only depends on the Config
+and the amount of nodes, so the instances agree on the peers of each other. Every
+graph is connected and the degree of its nodes is bounded by MaxDegree:
+
+- random-regular connects a random ring and adds random edges until every node has Degree peers
+- ring connects the nodes in a random order, every node has 2 peers
+- small-world rewires a random ring lattice of Degree peers per node with the Rewire probability
+- full-mesh connects every node to every other node
+- star connects the Hubs first nodes to each other and every other node to a single hub, round-robin
+
+A Degree of 0 generates a graph without edges, i.e. the validators don't know each other.
+
+	cfg := graphkit.Config{Kind: graphkit.RandomRegular, Degree: 3, Seed: seed}
+	g, err := cfg.Generate(len(validators))
+	peers := g.Peers[i]
+
+Config.Check verifies these guarantees, the tests of graphkit check them over many configs
+and seeds. cmd/print-peer-graph prints the graph of a config
+*/
+package graphkit
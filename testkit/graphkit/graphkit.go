@@ -0,0 +1,316 @@
+package graphkit
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// Kind is the shape of a peer graph
+type Kind string
+
+const (
+	RandomRegular Kind = "random-regular"
+	Ring          Kind = "ring"
+	SmallWorld    Kind = "small-world"
+	FullMesh      Kind = "full-mesh"
+	Star          Kind = "star"
+)
+
+// Kinds are all the supported kinds
+var Kinds = []Kind{RandomRegular, Ring, SmallWorld, FullMesh, Star}
+
+// maxAttempts bounds the draws of a connected small-world graph
+const maxAttempts = 100
+
+// Config selects the graph generated for a set of nodes
+type Config struct {
+	Kind Kind
+	// Degree is the amount of peers of every node, at least 2 to keep the graph connected.
+	// It is ignored by the ring, the full mesh and the star
+	Degree int
+	// Rewire is the probability the small-world graph rewires an edge of its ring lattice
+	Rewire float64
+	// Hubs is the amount of centers of the star, the first nodes
+	Hubs int
+	Seed int64
+}
+
+func (c Config) Validate() error {
+	switch c.Kind {
+	case RandomRegular:
+		if c.Degree < 0 {
+			return fmt.Errorf("degree must be >= 0, got %d", c.Degree)
+		}
+		return nil
+	case SmallWorld:
+		if c.Degree < 0 {
+			return fmt.Errorf("degree must be >= 0, got %d", c.Degree)
+		}
+		if c.Rewire < 0 || c.Rewire > 1 {
+			return fmt.Errorf("rewire must be within [0, 1], got %v", c.Rewire)
+		}
+		return nil
+	case Ring, FullMesh:
+		return nil
+	case Star:
+		if c.Hubs < 1 {
+			return fmt.Errorf("hubs must be >= 1, got %d", c.Hubs)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown peer graph %q, supported are %v", c.Kind, Kinds)
+	}
+}
+
+// MaxDegree is the bound of the degree of the nodes of a graph of n nodes
+func (c Config) MaxDegree(n int) int {
+	var d int
+	switch c.Kind {
+	case RandomRegular:
+		d = max(c.Degree, 2)
+	case SmallWorld:
+		d = 2 * max(c.Degree, 2)
+	case Ring:
+		d = 2
+	case FullMesh:
+		d = n - 1
+	case Star:
+		// a hub is connected to the other hubs and to its share of the leaves
+		hubs := max(min(c.Hubs, n), 1)
+		d = hubs - 1 + (n-hubs+hubs-1)/hubs
+	}
+	return min(d, n-1)
+}
+
+// Graph is an undirected graph without loops, the nodes are numbered from 0 to n-1
+type Graph struct {
+	// Peers are the sorted neighbours of every node
+	Peers [][]int
+}
+
+// Generate returns the graph of n nodes. The graph only depends on the Config and n,
+// so every instance generates the same one. It is connected and the degree of its nodes
+// is at most MaxDegree, unless the Degree is 0 which generates a graph without edges
+func (c Config) Generate(n int) (*Graph, error) {
+	err := c.Validate()
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("amount of nodes must be >= 0, got %d", n)
+	}
+
+	r := rand.New(rand.NewSource(c.Seed))
+	g := newGraph(n)
+	if n < 2 || (c.Degree == 0 && (c.Kind == RandomRegular || c.Kind == SmallWorld)) {
+		return g, nil
+	}
+
+	switch c.Kind {
+	case RandomRegular:
+		g.randomRegular(r, c.MaxDegree(n))
+	case Ring:
+		g.ring(r.Perm(n))
+	case SmallWorld:
+		g, err = smallWorld(r, n, max(c.Degree, 2), c.Rewire, c.MaxDegree(n))
+		if err != nil {
+			return nil, err
+		}
+	case FullMesh:
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				g.connect(i, j)
+			}
+		}
+	case Star:
+		hubs := min(c.Hubs, n)
+		for i := 0; i < hubs; i++ {
+			for j := i + 1; j < hubs; j++ {
+				g.connect(i, j)
+			}
+		}
+		for leaf := hubs; leaf < n; leaf++ {
+			g.connect(leaf, (leaf-hubs)%hubs)
+		}
+	}
+
+	g.sort()
+	return g, nil
+}
+
+// Check verifies the guarantees of a graph generated from the Config
+func (c Config) Check(g *Graph) error {
+	n := len(g.Peers)
+	edgeless := c.Degree == 0 && (c.Kind == RandomRegular || c.Kind == SmallWorld)
+	for i, peers := range g.Peers {
+		if edgeless && len(peers) > 0 {
+			return fmt.Errorf("node %d has %d peers with degree 0", i, len(peers))
+		}
+		if len(peers) > c.MaxDegree(n) {
+			return fmt.Errorf("node %d has %d peers, more than %d", i, len(peers), c.MaxDegree(n))
+		}
+		for k, j := range peers {
+			if j == i || j < 0 || j >= n {
+				return fmt.Errorf("node %d has an invalid peer %d", i, j)
+			}
+			if k > 0 && peers[k-1] >= j {
+				return fmt.Errorf("peers of node %d are not sorted or repeated: %v", i, peers)
+			}
+			if !g.Connected(j, i) {
+				return fmt.Errorf("node %d is a peer of %d but not the other way around", j, i)
+			}
+		}
+	}
+	if !edgeless && !g.IsConnected() {
+		return fmt.Errorf("graph of %d nodes is not connected", n)
+	}
+	return nil
+}
+
+// Connected tells whether j is a peer of i
+func (g *Graph) Connected(i, j int) bool {
+	for _, k := range g.Peers[i] {
+		if k == j {
+			return true
+		}
+	}
+	return false
+}
+
+// IsConnected tells whether every node is reachable from every other node
+func (g *Graph) IsConnected() bool {
+	n := len(g.Peers)
+	if n == 0 {
+		return true
+	}
+
+	seen := make([]bool, n)
+	seen[0] = true
+	stack, reached := []int{0}, 1
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, j := range g.Peers[i] {
+			if !seen[j] {
+				seen[j] = true
+				reached++
+				stack = append(stack, j)
+			}
+		}
+	}
+	return reached == n
+}
+
+// Edges returns every edge once, the lower node first
+func (g *Graph) Edges() [][2]int {
+	var edges [][2]int
+	for i, peers := range g.Peers {
+		for _, j := range peers {
+			if i < j {
+				edges = append(edges, [2]int{i, j})
+			}
+		}
+	}
+	return edges
+}
+
+func newGraph(n int) *Graph {
+	return &Graph{Peers: make([][]int, n)}
+}
+
+// connect adds the edge unless it is a loop or already exists
+func (g *Graph) connect(i, j int) {
+	if i == j || g.Connected(i, j) {
+		return
+	}
+	g.Peers[i] = append(g.Peers[i], j)
+	g.Peers[j] = append(g.Peers[j], i)
+}
+
+func (g *Graph) disconnect(i, j int) {
+	g.Peers[i] = remove(g.Peers[i], j)
+	g.Peers[j] = remove(g.Peers[j], i)
+}
+
+func (g *Graph) sort() {
+	for _, peers := range g.Peers {
+		sort.Ints(peers)
+	}
+}
+
+// ring connects the nodes in the order of the permutation
+func (g *Graph) ring(perm []int) {
+	for k := range perm {
+		g.connect(perm[k], perm[(k+1)%len(perm)])
+	}
+}
+
+// randomRegular starts from a random ring, which keeps the graph connected, and adds
+// the edges between random pairs of nodes with less than degree peers. Every node ends
+// up with degree peers, except a few when no such pair is left
+func (g *Graph) randomRegular(r *rand.Rand, degree int) {
+	n := len(g.Peers)
+	g.ring(r.Perm(n))
+
+	pairs := make([][2]int, 0, n*(n-1)/2)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			pairs = append(pairs, [2]int{i, j})
+		}
+	}
+	r.Shuffle(len(pairs), func(a, b int) { pairs[a], pairs[b] = pairs[b], pairs[a] })
+
+	for _, p := range pairs {
+		if len(g.Peers[p[0]]) < degree && len(g.Peers[p[1]]) < degree {
+			g.connect(p[0], p[1])
+		}
+	}
+}
+
+// smallWorld is the Watts-Strogatz graph: a ring lattice of the nodes in random order, where
+// every node is connected to its degree/2 closest nodes on each side, and every edge is
+// rewired to a random node with the given probability. Rewirings disconnecting the graph
+// are drawn again
+func smallWorld(r *rand.Rand, n, degree int, rewire float64, maxDegree int) (*Graph, error) {
+	half := max(degree/2, 1)
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		perm := r.Perm(n)
+		g := newGraph(n)
+		for k := range perm {
+			for d := 1; d <= half; d++ {
+				g.connect(perm[k], perm[(k+d)%n])
+			}
+		}
+
+		for k := range perm {
+			for d := 1; d <= half; d++ {
+				i, j := perm[k], perm[(k+d)%n]
+				if r.Float64() >= rewire || !g.Connected(i, j) {
+					continue
+				}
+				w := r.Intn(n)
+				if w == i || w == j || len(g.Peers[w]) >= maxDegree || g.Connected(i, w) {
+					continue
+				}
+				g.disconnect(i, j)
+				g.connect(i, w)
+			}
+		}
+
+		g.sort()
+		if g.IsConnected() {
+			return g, nil
+		}
+	}
+	return nil, fmt.Errorf("no connected small-world graph of %d nodes in %d attempts", n, maxAttempts)
+}
+
+func remove(peers []int, j int) []int {
+	for k, p := range peers {
+		if p == j {
+			return append(peers[:k], peers[k+1:]...)
+		}
+	}
+	return peers
+}
@@ -0,0 +1,139 @@
+package graphkit
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+const (
+	maxNodes  = 48
+	maxDegree = 6
+	maxHubs   = 3
+	seeds     = 10
+)
+
+var rewires = []float64{0, 0.1, 0.5, 1}
+
+// configs returns the configs of every kind within the bounds, without the seed
+func configs() []Config {
+	cfgs := []Config{
+		{Kind: Ring},
+		{Kind: FullMesh},
+	}
+	for d := 0; d <= maxDegree; d++ {
+		cfgs = append(cfgs, Config{Kind: RandomRegular, Degree: d})
+		for _, p := range rewires {
+			cfgs = append(cfgs, Config{Kind: SmallWorld, Degree: d, Rewire: p})
+		}
+	}
+	for h := 1; h <= maxHubs; h++ {
+		cfgs = append(cfgs, Config{Kind: Star, Hubs: h})
+	}
+	return cfgs
+}
+
+// TestGenerate checks the guarantees of the graphs of every kind, amount of nodes,
+// degree, hubs and rewiring probability within the bounds, and for many seeds
+func TestGenerate(t *testing.T) {
+	seeds := seeds
+	if testing.Short() {
+		seeds = 2
+	}
+
+	for _, cfg := range configs() {
+		cfg := cfg
+		t.Run(fmt.Sprintf("%s/degree=%d/rewire=%v/hubs=%d", cfg.Kind, cfg.Degree, cfg.Rewire, cfg.Hubs), func(t *testing.T) {
+			t.Parallel()
+			for n := 0; n <= maxNodes; n++ {
+				for s := 0; s < seeds; s++ {
+					cfg.Seed = int64(s)
+					err := checkGraph(cfg, n)
+					if err != nil {
+						t.Errorf("seed %d with %d nodes: %s", s, n, err)
+					}
+				}
+			}
+		})
+	}
+}
+
+// checkGraph generates the graph of n nodes and checks it's connected, its degree is
+// bounded by MaxDegree, its edges are symmetric without loops nor duplicates, and
+// it's generated again from the same config
+func checkGraph(cfg Config, n int) error {
+	g, err := cfg.Generate(n)
+	if err != nil {
+		return err
+	}
+	if len(g.Peers) != n {
+		return fmt.Errorf("%d nodes generated", len(g.Peers))
+	}
+
+	edgeless := cfg.Degree == 0 && (cfg.Kind == RandomRegular || cfg.Kind == SmallWorld)
+	if !edgeless && !g.IsConnected() {
+		return fmt.Errorf("not connected")
+	}
+
+	for i, peers := range g.Peers {
+		if edgeless && len(peers) > 0 {
+			return fmt.Errorf("node %d has %d peers with degree 0", i, len(peers))
+		}
+		if len(peers) > cfg.MaxDegree(n) {
+			return fmt.Errorf("node %d has %d peers, more than %d", i, len(peers), cfg.MaxDegree(n))
+		}
+
+		seen := make(map[int]bool, len(peers))
+		for _, j := range peers {
+			if j == i || seen[j] {
+				return fmt.Errorf("node %d has a loop or a duplicated peer: %v", i, peers)
+			}
+			seen[j] = true
+			if !g.Connected(j, i) {
+				return fmt.Errorf("node %d is a peer of %d but not the other way around", j, i)
+			}
+		}
+	}
+
+	err = cfg.Check(g)
+	if err != nil {
+		return fmt.Errorf("rejected by Check: %w", err)
+	}
+
+	again, err := cfg.Generate(n)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(g, again) {
+		return fmt.Errorf("a different graph is generated from the same config")
+	}
+	return nil
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   Config
+		peers [][]int
+		err   bool
+	}{
+		{name: "ring", cfg: Config{Kind: Ring}, peers: [][]int{{1, 2}, {0, 2}, {0, 1}}},
+		{name: "no node", cfg: Config{Kind: Ring}, peers: [][]int{}},
+		{name: "edgeless", cfg: Config{Kind: RandomRegular}, peers: [][]int{{}, {}, {}}},
+		{name: "edge with degree 0", cfg: Config{Kind: SmallWorld}, peers: [][]int{{1}, {0}, {}}, err: true},
+		{name: "not connected", cfg: Config{Kind: RandomRegular, Degree: 2}, peers: [][]int{{1}, {0}, {3}, {2}}, err: true},
+		{name: "above the max degree", cfg: Config{Kind: Ring}, peers: [][]int{{1, 2, 3}, {0}, {0}, {0}}, err: true},
+		{name: "asymmetric", cfg: Config{Kind: FullMesh}, peers: [][]int{{1, 2}, {0, 2}, {1}}, err: true},
+		{name: "loop", cfg: Config{Kind: FullMesh}, peers: [][]int{{0, 1}, {0}}, err: true},
+		{name: "duplicated peer", cfg: Config{Kind: FullMesh}, peers: [][]int{{1, 1}, {0}}, err: true},
+		{name: "unknown peer", cfg: Config{Kind: FullMesh}, peers: [][]int{{2}, {0}}, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Check(&Graph{Peers: tt.peers})
+			if (err != nil) != tt.err {
+				t.Errorf("expected an error: %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
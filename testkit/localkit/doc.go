@@ -0,0 +1,34 @@
+/*
+Package localkit runs a test case in a single process, without testground
+
+Every instance of the composition runs in its own goroutine with a RunEnv
+and an InitContext built the way testground builds them:
+
+  - the RunEnv has the merged global and group params, the instance and group counts
+    and an outputs directory per instance
+  - the SyncClient is a client of an in-memory synckit.Service shared by the instances
+  - the NetClient is the sidecar-less network.Client of the sdk, so configuring the
+    network is a no-op and the data network IP of every instance is 127.0.0.1
+  - the global and group sequence numbers are assigned in the order of the groups
+    and their instances, so they are the same from one run to the other
+
+Run returns once all the instances are finished:
+
+	results, err := localkit.Run(ctx, localkit.Composition{
+		Case:   "002-da-sync",
+		Params: map[string]string{"execution-time": "5", "validator": "1", ...},
+		Groups: []localkit.Group{
+			{ID: "validators", Instances: 1, Params: map[string]string{"role": "validator"}},
+			{ID: "bridges", Instances: 1, Params: map[string]string{"role": "bridge"}},
+		},
+	}, bigblocks.SyncNodes)
+
+The instances share the network stack and the filesystem of the process. The DA nodes
+listen on the loopback address on ports picked by the system (see nodekit.NewConfig),
+but the validators listen on the fixed ports of celestia-appd in the same home, so a
+composition runs a single validator and no seed, like 002-da-sync-4. The defaults
+of the manifest are not applied, see cmd/run-local for running a composition file.
+InitContext.WaitAllInstancesInitialized and WaitGroupInstancesInitialized rely on
+a field only testground can set, use waitkit.ForBarrier with run.StateInitializedGlobal instead.
+*/
+package localkit
@@ -0,0 +1,219 @@
+package localkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	gosync "sync"
+	"time"
+
+	"github.com/celestiaorg/test-infra/testkit/synckit"
+	"github.com/testground/sdk-go/network"
+	"github.com/testground/sdk-go/ptypes"
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+	"github.com/testground/sdk-go/sync"
+)
+
+// Composition is the part of a testground composition needed to run it locally
+type Composition struct {
+	// Plan defaults to "celestia"
+	Plan string
+	Case string
+	// Params are the global test params, the params of a group override them
+	Params map[string]string
+	Groups []Group
+	// OutputsPath is where the outputs of every instance are written,
+	// in <group id>/<group seq>. Defaults to a new temporary directory
+	OutputsPath string
+}
+
+// Group is a set of instances running with the same params
+type Group struct {
+	ID        string
+	Instances int
+	Params    map[string]string
+}
+
+// Result is the outcome of an instance
+type Result struct {
+	GroupID   string
+	GlobalSeq int64
+	GroupSeq  int64
+	Err       error
+}
+
+type instance struct {
+	runenv  *runtime.RunEnv
+	initCtx *run.InitContext
+}
+
+// Run runs all the instances of the composition with the test case, which is either
+// a run.TestCaseFn or a run.InitializedTestCaseFn, and waits for them to finish.
+// The error joins the errors of the failed instances.
+// When ctx is done first, Run returns without waiting for the instances, as the
+// test cases don't take a context from the runner
+func Run(ctx context.Context, c Composition, testcase interface{}) ([]Result, error) {
+	switch testcase.(type) {
+	case run.TestCaseFn, run.InitializedTestCaseFn:
+	default:
+		return nil, fmt.Errorf("unexpected test case %T, expected a run.TestCaseFn or a run.InitializedTestCaseFn", testcase)
+	}
+
+	instances, err := c.instances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		results = make([]Result, len(instances))
+		wg      gosync.WaitGroup
+		done    = make(chan struct{})
+	)
+	for i, inst := range instances {
+		wg.Add(1)
+		go func(i int, inst *instance) {
+			defer wg.Done()
+			results[i] = inst.run(testcase)
+		}(i, inst)
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var errs []error
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("instance %d of group %s: %w", r.GroupSeq, r.GroupID, r.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// instances creates the RunEnv and the InitContext of every instance, claiming their
+// sequence numbers in the order of the groups
+func (c Composition) instances(ctx context.Context) ([]*instance, error) {
+	total := 0
+	for _, g := range c.Groups {
+		if g.Instances < 1 {
+			return nil, fmt.Errorf("group %q must have at least 1 instance, got %d", g.ID, g.Instances)
+		}
+		total += g.Instances
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("composition of %s has no groups", c.Case)
+	}
+
+	outputs := c.OutputsPath
+	if outputs == "" {
+		var err error
+		outputs, err = os.MkdirTemp("", "localkit-*")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	plan := c.Plan
+	if plan == "" {
+		plan = "celestia"
+	}
+
+	_, subnet, _ := net.ParseCIDR("127.1.0.0/16")
+	var (
+		svc       = synckit.NewService()
+		runID     = "local-" + strconv.FormatInt(time.Now().Unix(), 10)
+		startTime = time.Now()
+		instances = make([]*instance, 0, total)
+	)
+	for _, g := range c.Groups {
+		params := make(map[string]string, len(c.Params)+len(g.Params))
+		for k, v := range c.Params {
+			params[k] = v
+		}
+		for k, v := range g.Params {
+			params[k] = v
+		}
+
+		for i := 0; i < g.Instances; i++ {
+			client := svc.NewClient()
+			globalSeq := client.MustSignalEntry(ctx, run.StateInitializedGlobal)
+			groupSeq := client.MustSignalEntry(ctx, sync.State(fmt.Sprintf(run.StateInitializedGroupFmt, g.ID)))
+
+			outputsPath := filepath.Join(outputs, g.ID, strconv.FormatInt(groupSeq, 10))
+			err := os.MkdirAll(outputsPath, 0o755)
+			if err != nil {
+				return nil, err
+			}
+
+			runenv := runtime.NewRunEnv(runtime.RunParams{
+				TestPlan:               plan,
+				TestCase:               c.Case,
+				TestRun:                runID,
+				TestOutputsPath:        outputsPath,
+				TestTempPath:           os.TempDir(),
+				TestInstanceCount:      total,
+				TestInstanceParams:     params,
+				TestGroupID:            g.ID,
+				TestGroupInstanceCount: g.Instances,
+				TestSidecar:            false,
+				TestSubnet:             &ptypes.IPNet{IPNet: *subnet},
+				TestStartTime:          startTime,
+			})
+			runenv.AttachSyncClient(client)
+
+			instances = append(instances, &instance{
+				runenv: runenv,
+				initCtx: &run.InitContext{
+					SyncClient: client,
+					NetClient:  network.NewClient(client, runenv),
+					GlobalSeq:  globalSeq,
+					GroupSeq:   groupSeq,
+				},
+			})
+		}
+	}
+	return instances, nil
+}
+
+// run runs the test case and records its outcome like the testground invoker does
+func (inst *instance) run(testcase interface{}) (res Result) {
+	res = Result{
+		GroupID:   inst.runenv.TestGroupID,
+		GlobalSeq: inst.initCtx.GlobalSeq,
+		GroupSeq:  inst.initCtx.GroupSeq,
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			res.Err = fmt.Errorf("panic: %v", r)
+			inst.runenv.RecordCrash(r)
+		} else if res.Err != nil {
+			inst.runenv.RecordFailure(res.Err)
+		} else {
+			inst.runenv.RecordSuccess()
+		}
+
+		_ = inst.initCtx.SyncClient.Close()
+		_ = inst.runenv.Close()
+	}()
+
+	inst.runenv.RecordStart()
+	switch fn := testcase.(type) {
+	case run.TestCaseFn:
+		res.Err = fn(inst.runenv)
+	case run.InitializedTestCaseFn:
+		res.Err = fn(inst.runenv, inst.initCtx)
+	}
+	return res
+}
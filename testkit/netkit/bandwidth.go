@@ -0,0 +1,76 @@
+package netkit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// BandwidthError is returned when a bandwidth param can't be converted into bytes per second
+type BandwidthError struct {
+	Value  string
+	Reason string
+}
+
+func (e *BandwidthError) Error() string {
+	return fmt.Sprintf("invalid bandwidth %q: %s", e.Value, e.Reason)
+}
+
+// bandwidthUnits maps a unit suffix to the amount of bytes per second it stands for.
+// The prefixed units are case-insensitive, the others aren't, as "B" is a byte and "b"
+// a bit. Longer suffixes go first, so "Mbit" is not taken for "bit"
+var bandwidthUnits = []struct {
+	suffix string
+	bytes  float64
+	// fold makes the suffix case-insensitive
+	fold bool
+}{
+	{"gbit", 1e9 / 8, true},
+	{"mbit", 1e6 / 8, true},
+	{"kbit", 1e3 / 8, true},
+	{"gib", 1 << 30, true},
+	{"mib", 1 << 20, true},
+	{"kib", 1 << 10, true},
+	{"bit", 1.0 / 8, true},
+	{"Bps", 1, false},
+	{"bps", 1.0 / 8, false},
+	{"B", 1, false},
+	{"b", 1.0 / 8, false},
+}
+
+// ParseBandwidth converts the bandwidth param into bytes per second,
+// as testground doesn't have native support of uint64 conversion from .toml files.
+// Supported units are Kib/Mib/Gib (binary bytes, e.g. "256Mib" is 256 * 2^20 B/s),
+// Kbit/Mbit/Gbit (decimal bits) and bit in any case, B and Bps for bytes and b and bps
+// for bits. A value without unit is taken as B/s
+func ParseBandwidth(v string) (uint64, error) {
+	s := strings.TrimSpace(v)
+	if s == "" {
+		return 0, &BandwidthError{Value: v, Reason: "empty value"}
+	}
+
+	multiplier := 1.0
+	for _, u := range bandwidthUnits {
+		if strings.HasSuffix(s, u.suffix) || (u.fold && strings.HasSuffix(strings.ToLower(s), u.suffix)) {
+			s = strings.TrimSpace(s[:len(s)-len(u.suffix)])
+			multiplier = u.bytes
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, &BandwidthError{Value: v, Reason: "not a number followed by a known unit"}
+	}
+	if n < 0 || math.IsNaN(n) {
+		return 0, &BandwidthError{Value: v, Reason: "must not be negative"}
+	}
+
+	bytes := math.Round(n * multiplier)
+	if bytes >= math.MaxUint64 {
+		return 0, &BandwidthError{Value: v, Reason: "overflows uint64"}
+	}
+
+	return uint64(bytes), nil
+}
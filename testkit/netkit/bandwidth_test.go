@@ -0,0 +1,70 @@
+package netkit
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseBandwidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  uint64
+		err   bool
+	}{
+		{name: "no unit", value: "1024", want: 1024},
+		{name: "spaces", value: "  2 Mib ", want: 2 << 20},
+		{name: "fraction", value: "1.5Kib", want: 1536},
+		{name: "Kib", value: "1Kib", want: 1 << 10},
+		{name: "KiB", value: "1KiB", want: 1 << 10},
+		{name: "kib", value: "1kib", want: 1 << 10},
+		{name: "Mib", value: "256Mib", want: 256 << 20},
+		{name: "MIB", value: "256MIB", want: 256 << 20},
+		{name: "Gib", value: "1Gib", want: 1 << 30},
+		{name: "GiB", value: "2GiB", want: 2 << 30},
+		{name: "Kbit", value: "8Kbit", want: 1000},
+		{name: "kbit", value: "8kbit", want: 1000},
+		{name: "Mbit", value: "10Mbit", want: 1250000},
+		{name: "MBIT", value: "10MBIT", want: 1250000},
+		{name: "Gbit", value: "1Gbit", want: 125000000},
+		{name: "gbit", value: "1gbit", want: 125000000},
+		{name: "bit", value: "80bit", want: 10},
+		{name: "Bit", value: "80Bit", want: 10},
+		{name: "B is a byte", value: "100B", want: 100},
+		{name: "b is a bit", value: "100b", want: 13},
+		{name: "Bps is a byte", value: "100Bps", want: 100},
+		{name: "bps is a bit", value: "100bps", want: 13},
+		{name: "zero", value: "0Mib", want: 0},
+		{name: "empty", value: "", err: true},
+		{name: "blank", value: "   ", err: true},
+		{name: "unit only", value: "Mib", err: true},
+		{name: "unknown unit", value: "10Tib", err: true},
+		{name: "wrong case of B", value: "10bPS", err: true},
+		{name: "not a number", value: "tenMib", err: true},
+		{name: "negative", value: "-1Mib", err: true},
+		{name: "NaN", value: "NaN", err: true},
+		{name: "infinite", value: "Inf", err: true},
+		{name: "overflow", value: "1e30Gib", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBandwidth(tt.value)
+			if tt.err {
+				var bwErr *BandwidthError
+				if !errors.As(err, &bwErr) {
+					t.Fatalf("ParseBandwidth(%q) = %d, %v, want a *BandwidthError", tt.value, got, err)
+				}
+				if bwErr.Value != tt.value {
+					t.Errorf("BandwidthError.Value = %q, want %q", bwErr.Value, tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseBandwidth(%q) failed: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseBandwidth(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+/*
+Package netkit is a helper around the network shaping of testground instances
+
+Every instance has to wait for the sidecar to initialise the data network and
+then to apply its own link shape, together with an IP address that is derived
+from the GlobalSeq. ConfigureNetwork does all of it from the runenv params:
+
+- latency, jitter: default egress latency and jitter in milliseconds
+- bandwidth: default egress bandwidth with units, e.g. "256Mib" or "10Mbit"
+- loss: default egress packet loss in percents
+- link-shapes: JSON object of per-role link shapes overriding the default one
+- link-rules: JSON array of link shapes applied to the traffic towards a subnet
+
+A composition that models light nodes sitting far away from the validators:
+
+	[global.run.test_params]
+	  latency = "0"
+	  bandwidth = "1024Mib"
+	  link-shapes = '{"light": {"latency": 200, "jitter": 20, "bandwidth": "100Mib", "loss": 0.5}}'
+	  link-rules = '[{"subnet": "16.1.1.0/24", "latency": 50, "bandwidth": "256Mib"}]'
+
+config, err := netkit.ConfigureNetwork(ctx, runenv, initCtx)
+
+A Partition splits the instances into groups by role and by fraction of the role,
+and PartitionRules returns the link rules dropping the traffic towards the other groups:
+
+	partition, err := netkit.ParsePartition(`[["validator:0-0.5"], ["validator:0.5-1"]]`)
+	rules := netkit.PartitionRules(runenv.TestSubnet, initCtx.GlobalSeq, partition.Groups(members))
+*/
+package netkit
@@ -0,0 +1,177 @@
+package netkit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/testground/sdk-go/network"
+	"github.com/testground/sdk-go/ptypes"
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+	"github.com/testground/sdk-go/sync"
+)
+
+// CallbackState is signalled by the sidecar once the link shape is applied
+const CallbackState = sync.State("network-configured")
+
+// LinkShape is the composition representation of a network.LinkShape
+type LinkShape struct {
+	// Latency is the egress latency in milliseconds
+	Latency int `json:"latency"`
+	// Jitter is the egress jitter in milliseconds
+	Jitter int `json:"jitter"`
+	// Bandwidth is the egress bandwidth, e.g. "100Mib"
+	Bandwidth string `json:"bandwidth"`
+	// Loss is the egress packet loss in percents
+	Loss float32 `json:"loss"`
+}
+
+// LinkRule applies a LinkShape to the traffic towards the given subnet
+type LinkRule struct {
+	LinkShape
+	Subnet string `json:"subnet"`
+}
+
+// ToNetwork converts the shape into the testground one
+func (ls LinkShape) ToNetwork() (network.LinkShape, error) {
+	bandwidth, err := ParseBandwidth(ls.Bandwidth)
+	if err != nil {
+		return network.LinkShape{}, err
+	}
+
+	return network.LinkShape{
+		Latency:   time.Duration(ls.Latency) * time.Millisecond,
+		Jitter:    time.Duration(ls.Jitter) * time.Millisecond,
+		Bandwidth: bandwidth,
+		Loss:      ls.Loss,
+	}, nil
+}
+
+// ToNetwork converts the rule into the testground one
+func (lr LinkRule) ToNetwork() (network.LinkRule, error) {
+	_, subnet, err := net.ParseCIDR(lr.Subnet)
+	if err != nil {
+		return network.LinkRule{}, fmt.Errorf("invalid link rule subnet %q: %w", lr.Subnet, err)
+	}
+
+	shape, err := lr.LinkShape.ToNetwork()
+	if err != nil {
+		return network.LinkRule{}, err
+	}
+
+	return network.LinkRule{
+		LinkShape: shape,
+		Subnet:    ptypes.IPNet{IPNet: *subnet},
+	}, nil
+}
+
+// DefaultLinkShape reads the link shape declared by the latency, jitter, bandwidth
+// and loss params. Only bandwidth is mandatory
+func DefaultLinkShape(runenv *runtime.RunEnv) LinkShape {
+	ls := LinkShape{
+		Bandwidth: runenv.StringParam("bandwidth"),
+	}
+	if runenv.IsParamSet("latency") {
+		ls.Latency = runenv.IntParam("latency")
+	}
+	if runenv.IsParamSet("jitter") {
+		ls.Jitter = runenv.IntParam("jitter")
+	}
+	if runenv.IsParamSet("loss") {
+		ls.Loss = float32(runenv.FloatParam("loss"))
+	}
+	return ls
+}
+
+// Role returns the role of the instance which is used to look up its link shape.
+// Test-cases that don't have a `role` param are using the group id instead
+func Role(runenv *runtime.RunEnv) string {
+	if runenv.IsParamSet("role") {
+		return runenv.StringParam("role")
+	}
+	return runenv.TestGroupID
+}
+
+// InstanceIP returns the IP address of the instance in the test subnet.
+// The assigned `GlobalSequencer` id is used to fill in the last 2 octets
+func InstanceIP(subnet *ptypes.IPNet, globalSeq int64) *ptypes.IPNet {
+	ipC := byte((globalSeq >> 8) + 1)
+	ipD := byte(globalSeq)
+
+	ipv4 := *subnet
+	ipv4.IP = append(ipv4.IP[0:2:2], ipC, ipD)
+	return &ipv4
+}
+
+// NewConfig builds the network.Config of the instance. The role's entry of link-shapes
+// takes precedence over the default link shape, while link-rules are applied to every role
+func NewConfig(runenv *runtime.RunEnv, initCtx *run.InitContext) (*network.Config, error) {
+	shape := DefaultLinkShape(runenv)
+
+	if runenv.IsParamSet("link-shapes") {
+		shapes := make(map[string]LinkShape)
+		err := json.Unmarshal([]byte(runenv.StringParam("link-shapes")), &shapes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid link-shapes param: %w", err)
+		}
+		if s, ok := shapes[Role(runenv)]; ok {
+			shape = s
+		}
+	}
+
+	defaultShape, err := shape.ToNetwork()
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []network.LinkRule
+	if runenv.IsParamSet("link-rules") {
+		var lrs []LinkRule
+		err := json.Unmarshal([]byte(runenv.StringParam("link-rules")), &lrs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid link-rules param: %w", err)
+		}
+		for _, lr := range lrs {
+			rule, err := lr.ToNetwork()
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, rule)
+		}
+	}
+
+	return &network.Config{
+		Network:       network.DefaultDataNetwork,
+		Enable:        true,
+		Default:       defaultShape,
+		Rules:         rules,
+		CallbackState: CallbackState,
+		RoutingPolicy: network.AllowAll,
+		IPv4:          InstanceIP(runenv.TestSubnet, initCtx.GlobalSeq),
+	}, nil
+}
+
+// ConfigureNetwork waits for the data network to be initialised and then applies
+// the instance's config. The applied config is returned to the caller
+func ConfigureNetwork(ctx context.Context, runenv *runtime.RunEnv, initCtx *run.InitContext) (*network.Config, error) {
+	err := initCtx.NetClient.WaitNetworkInitialized(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := NewConfig(runenv, initCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	runenv.RecordMessage("Configuring network with %+v and %d link rules", config.Default, len(config.Rules))
+	err = initCtx.NetClient.ConfigureNetwork(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
@@ -0,0 +1,155 @@
+package netkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/testground/sdk-go/network"
+	"github.com/testground/sdk-go/ptypes"
+)
+
+// Selector selects the instances of a role from the From to the To fraction of them,
+// ordered by their global sequence number
+type Selector struct {
+	Role string
+	From float64
+	To   float64
+}
+
+// ParseSelector parses "role" or "role:from-to", e.g. "validator:0-0.5" is the first half of the validators
+func ParseSelector(s string) (Selector, error) {
+	role, fractions, ok := strings.Cut(strings.TrimSpace(s), ":")
+	sel := Selector{Role: role, From: 0, To: 1}
+	if role == "" {
+		return Selector{}, fmt.Errorf("selector %q has no role", s)
+	}
+	if !ok {
+		return sel, nil
+	}
+
+	from, to, ok := strings.Cut(fractions, "-")
+	if !ok {
+		return Selector{}, fmt.Errorf("selector %q: expected role:from-to", s)
+	}
+	var err error
+	sel.From, err = strconv.ParseFloat(from, 64)
+	if err != nil {
+		return Selector{}, fmt.Errorf("selector %q: invalid from %q", s, from)
+	}
+	sel.To, err = strconv.ParseFloat(to, 64)
+	if err != nil {
+		return Selector{}, fmt.Errorf("selector %q: invalid to %q", s, to)
+	}
+	if sel.From < 0 || sel.To > 1 || sel.From >= sel.To {
+		return Selector{}, fmt.Errorf("selector %q: expected 0 <= from < to <= 1", s)
+	}
+	return sel, nil
+}
+
+// Partition are the groups of instances which can't reach each other.
+// The instances which are not in any group reach all the others
+type Partition [][]Selector
+
+// ParsePartition parses the JSON array of groups of selectors, e.g. half of the validators
+// cut off from the other half: [["validator:0-0.5"], ["validator:0.5-1"]], or the light
+// nodes cut off from all the bridges: [["light"], ["bridge"]]. An empty string is no partition
+func ParsePartition(s string) (Partition, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var raw [][]string
+	err := json.Unmarshal([]byte(s), &raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid partition: %w", err)
+	}
+	if len(raw) < 2 {
+		return nil, fmt.Errorf("partition must have at least 2 groups, got %d", len(raw))
+	}
+
+	p := make(Partition, len(raw))
+	for i, group := range raw {
+		if len(group) == 0 {
+			return nil, fmt.Errorf("group %d of the partition is empty", i)
+		}
+		for _, s := range group {
+			sel, err := ParseSelector(s)
+			if err != nil {
+				return nil, err
+			}
+			p[i] = append(p[i], sel)
+		}
+	}
+	return p, nil
+}
+
+// Member is an instance taking part in a partition
+type Member struct {
+	GlobalSeq int64
+	Role      string
+}
+
+// Groups returns the group of every member by its global sequence number, or -1 when
+// the member is in no group. An instance selected by several groups is in the first one
+func (p Partition) Groups(members []Member) map[int64]int {
+	byRole := make(map[string][]int64)
+	for _, m := range members {
+		byRole[m.Role] = append(byRole[m.Role], m.GlobalSeq)
+	}
+	for _, seqs := range byRole {
+		sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	}
+
+	groups := make(map[int64]int, len(members))
+	for _, m := range members {
+		groups[m.GlobalSeq] = -1
+	}
+	for g := len(p) - 1; g >= 0; g-- {
+		for _, sel := range p[g] {
+			seqs := byRole[sel.Role]
+			n := float64(len(seqs))
+			for k, seq := range seqs {
+				if float64(k) >= sel.From*n && float64(k) < sel.To*n {
+					groups[seq] = g
+				}
+			}
+		}
+	}
+	return groups
+}
+
+// PartitionRules returns the link rules dropping the traffic of the instance towards
+// the instances of the other groups. The IPs are derived from the global sequence
+// numbers like InstanceIP does
+func PartitionRules(subnet *ptypes.IPNet, self int64, groups map[int64]int) []network.LinkRule {
+	own, ok := groups[self]
+	if !ok || own < 0 {
+		return nil
+	}
+
+	seqs := make([]int64, 0, len(groups))
+	for seq := range groups {
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+
+	var rules []network.LinkRule
+	for _, seq := range seqs {
+		g := groups[seq]
+		if g < 0 || g == own {
+			continue
+		}
+
+		ip := InstanceIP(subnet, seq)
+		rules = append(rules, network.LinkRule{
+			// the loss keeps the link cut where the sidecar ignores the filter
+			LinkShape: network.LinkShape{Filter: network.Drop, Loss: 100},
+			Subnet:    ptypes.IPNet{IPNet: net.IPNet{IP: ip.IP, Mask: net.CIDRMask(32, 32)}},
+		})
+	}
+	return rules
+}
@@ -2,6 +2,7 @@ package nodekit
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -9,7 +10,10 @@ import (
 
 	"github.com/celestiaorg/celestia-app/app"
 	"github.com/celestiaorg/celestia-app/app/encoding"
+	"github.com/cosmos/cosmos-sdk/crypto"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 
 	"github.com/celestiaorg/celestia-node/logs"
 	"github.com/celestiaorg/celestia-node/nodebuilder"
@@ -27,9 +31,17 @@ func NewConfig(
 	trustedHash string,
 ) *nodebuilder.Config {
 	cfg := nodebuilder.DefaultConfig(tp)
+	port := "2121"
+	if IP.IsLoopback() {
+		// the instances run by localkit share the loopback address, so each node
+		// listens on ports picked by the system. The nodes publish the addresses
+		// of their host, which have the actual ports
+		port = "0"
+		cfg.RPC.Port = "0"
+	}
 	cfg.P2P.ListenAddresses = []string{
-		fmt.Sprintf("/ip4/%s/udp/2121/quic-v1", IP),
-		fmt.Sprintf("/ip4/%s/tcp/2121", IP),
+		fmt.Sprintf("/ip4/%s/udp/%s/quic-v1", IP, port),
+		fmt.Sprintf("/ip4/%s/tcp/%s", IP, port),
 	}
 	cfg.Header.TrustedPeers = trustedPeers
 	cfg.Header.TrustedHash = trustedHash
@@ -38,23 +50,62 @@ func NewConfig(
 }
 
 func NewNode(path string, tp node.Type, network string, cfg *nodebuilder.Config, options ...fx.Option) (*nodebuilder.Node, error) {
+	nd, _, err := OpenNode(path, tp, network, cfg, options...)
+	return nd, err
+}
+
+// OpenNode is NewNode returning the store of the node as well. The store locks its
+// path, so it has to be closed once the node is stopped to build the node again
+func OpenNode(
+	path string,
+	tp node.Type,
+	network string,
+	cfg *nodebuilder.Config,
+	options ...fx.Option,
+) (*nodebuilder.Node, nodebuilder.Store, error) {
 	err := nodebuilder.Init(*cfg, path, tp)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	keysPath := filepath.Join(path, "keys")
-	encConf := encoding.MakeConfig(app.ModuleEncodingRegisters...)
-	ring, err := keyring.New(app.Name, cfg.State.KeyringBackend, keysPath, os.Stdin, encConf.Codec)
+	ring, err := newKeyring(path, cfg)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	store, err := nodebuilder.OpenStore(path, ring)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	nd, err := nodebuilder.NewWithConfig(tp, p2p.Network(network), store, cfg, options...)
+	if err != nil {
+		return nil, nil, errors.Join(err, store.Close())
+	}
+	return nd, store, nil
+}
+
+// ImportKey imports the private key into the keyring of the node at path under the
+// name of the account of the node, e.g. an account funded in the genesis
+func ImportKey(path string, cfg *nodebuilder.Config, priv cryptotypes.PrivKey) error {
+	if cfg.State.KeyringAccName == "" {
+		return fmt.Errorf("the keyring account name of the node must be set to import a key")
+	}
+
+	ring, err := newKeyring(path, cfg)
+	if err != nil {
+		return err
 	}
-	return nodebuilder.NewWithConfig(tp, p2p.Network(network), store, cfg, options...)
+
+	const passphrase = "prefunded"
+	armor := crypto.EncryptArmorPrivKey(priv, passphrase, string(hd.Secp256k1Type))
+	return ring.ImportPrivKey(cfg.State.KeyringAccName, armor, passphrase)
+}
+
+func newKeyring(path string, cfg *nodebuilder.Config) (keyring.Keyring, error) {
+	keysPath := filepath.Join(path, "keys")
+	encConf := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	return keyring.New(app.Name, cfg.State.KeyringBackend, keysPath, os.Stdin, encConf.Codec)
 }
 
 func IsSyncing(ctx context.Context, nd *nodebuilder.Node) bool {
@@ -65,6 +116,20 @@ func IsSyncing(ctx context.Context, nd *nodebuilder.Node) bool {
 	return !syncer.Finished()
 }
 
+// SamplingOutcome reports whether the DASer of the node is done with the height and whether
+// it failed to sample it. The DASer retries the failed heights, so a height is reported
+// failed as soon as it failed once
+func SamplingOutcome(ctx context.Context, nd *nodebuilder.Node, height uint64) (done, failed bool, err error) {
+	stats, err := nd.DASer.SamplingStats(ctx)
+	if err != nil {
+		return false, false, err
+	}
+	if stats.Failed[height] > 0 {
+		return true, true, nil
+	}
+	return stats.SampledChainHead >= height, false, nil
+}
+
 func SetLoggersLevel(lvl string) error {
 	level, err := logging.LevelFromString(lvl)
 	if err != nil {
@@ -0,0 +1,35 @@
+/*
+Package paramkit populates typed param structs from the runenv of an instance
+
+A test-case declares its params as a struct, where every field is tagged with
+the name of the param and optionally with its default value. Fields without a
+default are required. Nested and embedded structs are loaded as well, so common
+groups of params can be shared between test-cases:
+
+	type Params struct {
+		common.Topology
+		Getter         string `param:"getter" default:"shrex"`
+		Multibootstrap bool   `param:"multibootstrap" default:"false"`
+		ExecutionTime  int    `param:"execution-time"`
+	}
+
+	func (p *Params) Validate() error {
+		if err := p.Topology.Validate(); err != nil {
+			return err
+		}
+		if p.Bridge < 1 {
+			return fmt.Errorf("bridge must be >= 1, got %d", p.Bridge)
+		}
+		return nil
+	}
+
+Load reports every missing or malformed param at once and then runs the
+Validate method of every struct implementing Validator, so a misconfigured
+composition fails when the instance starts instead of half-way through the run.
+The Validate method of an embedded struct is promoted, so a struct overriding it
+has to call it explicitly, as above:
+
+var p Params
+err := paramkit.Load(runenv, &p)
+*/
+package paramkit
@@ -0,0 +1,143 @@
+package paramkit
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/testground/sdk-go/runtime"
+)
+
+const (
+	paramTag   = "param"
+	defaultTag = "default"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Validator is implemented by the params that have constraints beyond their types,
+// e.g. "bridge must be >= 1"
+type Validator interface {
+	Validate() error
+}
+
+// Load populates the struct pointed by params from the runenv and validates it.
+// All the missing and malformed params are reported together
+func Load(runenv *runtime.RunEnv, params interface{}) error {
+	v := reflect.ValueOf(params)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("params must be a pointer to a struct, got %T", params)
+	}
+
+	err := errors.Join(load(runenv.TestInstanceParams, v.Elem())...)
+	if err != nil {
+		return err
+	}
+	return errors.Join(validate(v.Elem())...)
+}
+
+// load sets the tagged fields of the struct, descending into the untagged struct fields
+func load(values map[string]string, v reflect.Value) []error {
+	var errs []error
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, fv := t.Field(i), v.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, ok := field.Tag.Lookup(paramTag)
+		if !ok {
+			if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+				errs = append(errs, load(values, fv)...)
+			}
+			continue
+		}
+
+		raw, ok := values[name]
+		if !ok {
+			raw, ok = field.Tag.Lookup(defaultTag)
+		}
+		if !ok {
+			errs = append(errs, fmt.Errorf("param %q is required", name))
+			continue
+		}
+
+		err := set(fv, raw)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("param %q: %w", name, err))
+		}
+	}
+	return errs
+}
+
+// set parses the raw value of a param into the field
+func set(fv reflect.Value, raw string) error {
+	if fv.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid bool %q", raw)
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(raw, 10, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		fv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(raw, 10, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer %q", raw)
+		}
+		fv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid float %q", raw)
+		}
+		fv.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+	return nil
+}
+
+// validate runs the Validate method of the nested structs first and then the one of the struct itself.
+// Embedded structs are skipped, as their Validate method is promoted to the struct embedding them
+func validate(v reflect.Value) []error {
+	var errs []error
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Anonymous || field.Type.Kind() != reflect.Struct || field.Type == durationType {
+			continue
+		}
+		if _, ok := field.Tag.Lookup(paramTag); ok {
+			continue
+		}
+		errs = append(errs, validate(v.Field(i))...)
+	}
+
+	if val, ok := v.Addr().Interface().(Validator); ok {
+		err := val.Validate()
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
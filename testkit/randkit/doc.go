@@ -0,0 +1,19 @@
+/*
+Package randkit makes the randomness of a run reproducible
+
+All the instances of a run share a seed, the random-seed param. Every instance
+derives its own RNGs from the seed, its global sequence number and the purpose of
+the RNG (e.g. "namespace"), so the instances draw different values, a purpose
+doesn't change the draws of another one, and re-running with the same seed
+draws the same values:
+
+- Seed reads the random-seed param, or derives it from the run ID when it is 0 or missing
+- New creates the RNG of an instance for a purpose
+
+seed := randkit.Seed(runenv)
+r := randkit.New(seed, initCtx.GlobalSeq, "namespace")
+
+The seed is recorded in the outputs of the instance, so a failing run is reproduced
+by setting random-seed to the recorded value
+*/
+package randkit
@@ -0,0 +1,51 @@
+package randkit
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math/rand"
+
+	"github.com/testground/sdk-go/runtime"
+)
+
+// Param is the name of the run-level seed param
+const Param = "random-seed"
+
+// Seed returns the seed shared by all the instances of the run
+func Seed(runenv *runtime.RunEnv) int64 {
+	if runenv.IsParamSet(Param) {
+		if seed := int64(runenv.IntParam(Param)); seed != 0 {
+			return seed
+		}
+	}
+	// the run ID is the same for all the instances, unlike the time they start at
+	return int64(mix(hash(runenv.TestRun)) >> 1)
+}
+
+// New returns the RNG of the instance with the given global sequence number for the purpose
+func New(seed, globalSeq int64, purpose string) *rand.Rand {
+	h := fnv.New64a()
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(seed))
+	_, _ = h.Write(buf[:])
+	binary.BigEndian.PutUint64(buf[:], uint64(globalSeq))
+	_, _ = h.Write(buf[:])
+	_, _ = h.Write([]byte(purpose))
+	return rand.New(rand.NewSource(int64(mix(h.Sum64()))))
+}
+
+func hash(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// mix is the splitmix64 finalizer, fnv barely changes the high bits for the last bytes
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
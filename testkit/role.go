@@ -0,0 +1,98 @@
+package testkit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/celestiaorg/test-infra/testkit/paramkit"
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+)
+
+// RoleFn is implemented by every helper that runs a single node type
+// of a test-case (e.g. nodesync.RunBridgeNode)
+type RoleFn func(runenv *runtime.RunEnv, initCtx *run.InitContext) error
+
+// Roles declares which roles a test-case supports and which helper runs each of them.
+// A plan only needs to register its roles and hand over the dispatching to Run:
+//
+//	var syncNodes = testkit.Roles{
+//		"validator": nodesync.RunAppValidator,
+//		"bridge":    nodesync.RunBridgeNode,
+//	}
+//
+//	func SyncNodes(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
+//		return syncNodes.Run(runenv, initCtx)
+//	}
+type Roles map[string]RoleFn
+
+// Run dispatches the instance to the helper registered under its `role` param
+func (r Roles) Run(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
+	var role string
+	if runenv.IsParamSet("role") {
+		role = runenv.StringParam("role")
+	}
+	return r.dispatch(runenv, initCtx, role)
+}
+
+// RunByGroup dispatches the instance to the helper registered under its group id.
+// This is used by the test-cases that don't declare a `role` param in the manifest
+func (r Roles) RunByGroup(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
+	return r.dispatch(runenv, initCtx, runenv.TestGroupID)
+}
+
+// RunWithParams loads and validates the typed params of the test-case before
+// dispatching the instance like Run, so a misconfigured composition fails at startup.
+// The helpers can load the same params again with paramkit.Load
+func (r Roles) RunWithParams(runenv *runtime.RunEnv, initCtx *run.InitContext, params interface{}) error {
+	err := paramkit.Load(runenv, params)
+	if err != nil {
+		return fail(runenv, initCtx, fmt.Errorf("invalid params: %w", err))
+	}
+	return r.Run(runenv, initCtx)
+}
+
+// RunByGroupWithParams is RunWithParams for the test-cases dispatched by group id
+func (r Roles) RunByGroupWithParams(runenv *runtime.RunEnv, initCtx *run.InitContext, params interface{}) error {
+	err := paramkit.Load(runenv, params)
+	if err != nil {
+		return fail(runenv, initCtx, fmt.Errorf("invalid params: %w", err))
+	}
+	return r.RunByGroup(runenv, initCtx)
+}
+
+// Names returns the sorted list of the registered roles
+func (r Roles) Names() []string {
+	names := make([]string, 0, len(r))
+	for name := range r {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// dispatch runs the helper of the given role. Any failure, including an unknown role,
+// is recorded and signalled to the FinishState, so other instances are not left waiting
+func (r Roles) dispatch(runenv *runtime.RunEnv, initCtx *run.InitContext, role string) (err error) {
+	fn, ok := r[role]
+	if !ok {
+		err = fmt.Errorf("unknown role %q, supported roles are %v", role, r.Names())
+	} else {
+		err = fn(runenv, initCtx)
+	}
+
+	if err != nil {
+		return fail(runenv, initCtx, err)
+	}
+
+	runenv.RecordSuccess()
+	return nil
+}
+
+// fail records the failure and signals the FinishState on behalf of the instance
+func fail(runenv *runtime.RunEnv, initCtx *run.InitContext, err error) error {
+	runenv.RecordFailure(err)
+	initCtx.SyncClient.MustSignalAndWait(context.Background(), FinishState, runenv.TestInstanceCount)
+	return err
+}
@@ -5,6 +5,8 @@ import (
 	"net"
 
 	"github.com/celestiaorg/test-infra/testkit/appkit"
+	"github.com/celestiaorg/test-infra/testkit/byzkit"
+	"github.com/celestiaorg/test-infra/testkit/netkit"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/testground/sdk-go/sync"
 )
@@ -26,6 +28,8 @@ var (
 type AppNodeInfo struct {
 	ID int
 	IP net.IP
+	// Zone is used to assign the bridges to the validators of their zone, see assignkit
+	Zone string
 }
 
 // BridgeNodeInfo is needed for creation of Celestia Full/Light instances
@@ -35,11 +39,55 @@ type BridgeNodeInfo struct {
 	Maddr       string
 	TrustedHash string
 	AddrInfo    peer.AddrInfo
+	// Zone is used to assign the full and light nodes to the bridges of their zone, see assignkit
+	Zone string
 }
 
 type FullNodeInfo struct {
 	ID    int
 	Maddr string
+	// Group is the test group of the full node, the full nodes only trust the ones of their group
+	Group string
+}
+
+// PeerGraph is the graph of the persistent peers of the validators (and seeds),
+// published for the analysis of a run. The edges refer to the index of the Nodes
+type PeerGraph struct {
+	Kind  string
+	Seed  int64
+	Nodes []string
+	Edges [][2]int
+}
+
+// PartitionReport is the height of an instance when a network partition heals
+type PartitionReport struct {
+	GlobalSeq int64
+	Role      string
+	// Group is the group of the instance in the partition, -1 if it was in none
+	Group  int
+	Height uint64
+	// Heights are the heights of the instance sampled during the partition, at a fixed
+	// interval since its start, so the heights of every instance at a time line up
+	Heights []uint64
+}
+
+// ByzantineReport are the heights a byzantine bridge actually attacked
+type ByzantineReport struct {
+	GlobalSeq int64
+	Attack    string
+	Heights   []uint64
+	// Squares are the attacked squares, with the amount of shares withheld from the light nodes
+	Squares []byzkit.Square
+}
+
+// WithholdingResult is how many of the withheld squares a light node detected,
+// against the sum of the detection probabilities and of their variances
+type WithholdingResult struct {
+	GlobalSeq int64
+	Squares   int
+	Detected  int
+	Expected  float64
+	Variance  float64
 }
 
 // These topics are used around Celestia Bridge/Full/Light instances
@@ -49,6 +97,13 @@ var (
 	BridgeNodeTopic  = sync.NewTopic("bridge-info", &BridgeNodeInfo{})
 	FullNodeTopic    = sync.NewTopic("full-info", &FullNodeInfo{})
 	FundAccountTopic = sync.NewTopic("account-addr", "")
+	PeerGraphTopic   = sync.NewTopic("peer-graph", &PeerGraph{})
+	// PartitionMemberTopic and PartitionReportTopic are used by all the instances taking part in a partition
+	PartitionMemberTopic = sync.NewTopic("partition-member", &netkit.Member{})
+	PartitionReportTopic = sync.NewTopic("partition-report", &PartitionReport{})
+	ByzantineReportTopic = sync.NewTopic("byzantine-report", &ByzantineReport{})
+	// WithholdingResultTopic is used by the light nodes sampling withheld squares
+	WithholdingResultTopic = sync.NewTopic("withholding-result", &WithholdingResult{})
 )
 
 // FinishState should be signaled by those, againts which we are testing
@@ -60,4 +115,9 @@ var (
 	FinishState              = sync.State("test-finished")
 	LightNodesStartedState   = sync.State("light-nodes-started")
 	ValidatorReadyTopic      = sync.State("validator-ready")
+	PartitionStartState      = sync.State("partition-start")
+	// ByzantineDetectedState is signaled by the full and light nodes done with the attacked heights
+	ByzantineDetectedState = sync.State("byzantine-detected")
+	// EVMAddressRegisteredState is signaled by the QGB validators once their EVM address is registered
+	EVMAddressRegisteredState = sync.State("evm-address-registered")
 )
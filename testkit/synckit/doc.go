@@ -0,0 +1,35 @@
+/*
+Package synckit is an in-memory stand-in for the testground sync service
+
+A Service holds the states and the topics of a run in memory, and every instance
+gets its own Client of it, which implements the sync.Client of the sdk. Payloads
+go through JSON like with the real service, so instances never share pointers and
+a subscriber receives a copy decoded into the element type of its channel.
+
+It is meant to run several instances in the same process, e.g. by localkit:
+
+	svc := synckit.NewService()
+	client := svc.NewClient()
+	defer client.Close()
+
+	_, err := client.Publish(ctx, testkit.BlockHashTopic, hash)
+
+Every subscriber receives the payloads of a topic in the order they were published.
+Subscription.Done is never closed, a subscription ends with its context or when
+its client is closed.
+
+A Service can inject faults to check how the helpers cope with them, e.g. every
+other bridge address published twice and barriers released up to 5s late:
+
+	svc := synckit.NewServiceWithFaults(synckit.Faults{
+		Seed:             1,
+		DuplicatePublish: 0.5,
+		Topics:           []string{"bridge-info"},
+		BarrierDelay:     5 * time.Second,
+	})
+
+The faulty operations are chosen from the Seed, so a failure is reproduced by
+running again with the same Seed. Delivered and Entries tell what the instances
+actually observed.
+*/
+package synckit
@@ -0,0 +1,80 @@
+package synckit
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"time"
+
+	"github.com/testground/sdk-go/sync"
+)
+
+// Faults are injected by a Service into the operations of its clients, to check
+// how the helpers cope with a misbehaving sync service.
+// Whether an operation is faulty only depends on the Seed and on the operation
+// itself (e.g. the 3rd publish to a topic), not on the interleaving of the instances,
+// so a run with the same Seed injects the same faults
+type Faults struct {
+	Seed int64
+	// DropPublish is the probability that a publish succeeds but is never delivered
+	DropPublish float64
+	// DuplicatePublish is the probability that a publish is delivered twice
+	DuplicatePublish float64
+	// Topics restricts the publish faults to the topics with these names, all by default
+	Topics []string
+	// BarrierDelay is the maximum delay of a barrier after reaching its target
+	BarrierDelay time.Duration
+}
+
+// NewServiceWithFaults creates an empty Service injecting the faults
+func NewServiceWithFaults(f Faults) *Service {
+	s := NewService()
+	s.faults = f
+	if len(f.Topics) > 0 {
+		s.faultyTopics = make(map[string]bool, len(f.Topics))
+		for _, name := range f.Topics {
+			s.faultyTopics[topicKey(sync.NewTopic(name, ""))] = true
+		}
+	}
+	return s
+}
+
+// publishFault tells whether the n-th publish to the topic is dropped or duplicated
+func (s *Service) publishFault(key string, n int64) (drop, duplicate bool) {
+	if s.faultyTopics != nil && !s.faultyTopics[key] {
+		return false, false
+	}
+
+	roll := s.roll("publish", key, n)
+	drop = roll < s.faults.DropPublish
+	duplicate = !drop && roll < s.faults.DropPublish+s.faults.DuplicatePublish
+	return drop, duplicate
+}
+
+// barrierDelay is the delay of a barrier on the state with the given target
+func (s *Service) barrierDelay(state sync.State, target int) time.Duration {
+	if s.faults.BarrierDelay <= 0 {
+		return 0
+	}
+	return time.Duration(s.roll("barrier", string(state), int64(target)) * float64(s.faults.BarrierDelay))
+}
+
+// roll maps the seed and the operation to a number in [0, 1)
+func (s *Service) roll(op, key string, n int64) float64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(s.faults.Seed))
+	_, _ = h.Write(buf[:])
+	_, _ = h.Write([]byte(op))
+	_, _ = h.Write([]byte(key))
+	binary.BigEndian.PutUint64(buf[:], uint64(n))
+	_, _ = h.Write(buf[:])
+
+	// fnv barely changes the high bits for the last bytes, so they are mixed like splitmix64 does
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return float64(x>>11) / (1 << 53)
+}
@@ -0,0 +1,390 @@
+package synckit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	gosync "sync"
+	"time"
+
+	"github.com/testground/sdk-go/runtime"
+	"github.com/testground/sdk-go/sync"
+)
+
+// ErrClosed is returned by the operations of a closed Client
+var ErrClosed = errors.New("sync client is closed")
+
+// Service holds the states and topics shared by the clients
+type Service struct {
+	mu gosync.Mutex
+	// changed is closed and replaced on every change, waking up all the waiters
+	changed chan struct{}
+	states  map[sync.State]int64
+	// topics hold the JSON encoded payloads to deliver, in the order they were published
+	topics map[string][][]byte
+	// published counts the publishes to every topic, including the dropped ones
+	published map[string]int64
+
+	faults       Faults
+	faultyTopics map[string]bool
+}
+
+// NewService creates an empty Service
+func NewService() *Service {
+	return &Service{
+		changed:   make(chan struct{}),
+		states:    make(map[sync.State]int64),
+		topics:    make(map[string][][]byte),
+		published: make(map[string]int64),
+	}
+}
+
+// Delivered returns the amount of payloads of the topic delivered to its subscribers
+func (s *Service) Delivered(topic *sync.Topic) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.topics[topicKey(topic)])
+}
+
+// Entries returns the amount of entries which signalled the state
+func (s *Service) Entries(state sync.State) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.states[state]
+}
+
+// NewClient creates a client of the service for an instance
+func (s *Service) NewClient() *Client {
+	return &Client{
+		svc:    s,
+		closed: make(chan struct{}),
+	}
+}
+
+// notify wakes up the waiters, the lock must be held
+func (s *Service) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// wait blocks until cond, which is called with the lock held, is satisfied
+func (s *Service) wait(ctx context.Context, closed <-chan struct{}, cond func() bool) error {
+	for {
+		s.mu.Lock()
+		ok, changed := cond(), s.changed
+		s.mu.Unlock()
+		if ok {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-closed:
+			return ErrClosed
+		}
+	}
+}
+
+// Client is the sync.Client of an instance
+type Client struct {
+	svc       *Service
+	closeOnce gosync.Once
+	closed    chan struct{}
+}
+
+var _ sync.Client = (*Client)(nil)
+
+// topicKey identifies the topic regardless of the run, as a Service only holds a single run
+func topicKey(topic *sync.Topic) string {
+	return topic.Key(&runtime.RunParams{})
+}
+
+func (c *Client) isClosed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Publish appends the payload to the topic and returns its sequence number, starting at 1.
+// Every subscriber receives the payloads of a topic in the same order
+func (c *Client) Publish(_ context.Context, topic *sync.Topic, payload interface{}) (int64, error) {
+	if c.isClosed() {
+		return -1, ErrClosed
+	}
+
+	key := topicKey(topic)
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return -1, fmt.Errorf("encoding payload of %s: %w", key, err)
+	}
+
+	c.svc.mu.Lock()
+	defer c.svc.mu.Unlock()
+	c.svc.published[key]++
+	seq := c.svc.published[key]
+
+	drop, duplicate := c.svc.publishFault(key, seq)
+	switch {
+	case drop:
+		return seq, nil
+	case duplicate:
+		c.svc.topics[key] = append(c.svc.topics[key], data, data)
+	default:
+		c.svc.topics[key] = append(c.svc.topics[key], data)
+	}
+	c.svc.notify()
+	return seq, nil
+}
+
+// Subscribe sends all the payloads of the topic to ch, the ones published before
+// the subscription included, until ctx is done or the client is closed.
+// A payload that doesn't decode into the element type of ch ends the subscription
+func (c *Client) Subscribe(ctx context.Context, topic *sync.Topic, ch interface{}) (*sync.Subscription, error) {
+	if c.isClosed() {
+		return nil, ErrClosed
+	}
+
+	chv := reflect.ValueOf(ch)
+	if chv.Kind() != reflect.Chan || chv.Type().ChanDir()&reflect.SendDir == 0 {
+		return nil, fmt.Errorf("subscribing to %s: expected a sendable channel, got %T", topicKey(topic), ch)
+	}
+
+	go c.deliver(ctx, topicKey(topic), chv)
+	return &sync.Subscription{}, nil
+}
+
+func (c *Client) deliver(ctx context.Context, key string, ch reflect.Value) {
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectSend, Chan: ch},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c.closed)},
+	}
+
+	for next := 0; ; next++ {
+		var data []byte
+		err := c.svc.wait(ctx, c.closed, func() bool {
+			payloads := c.svc.topics[key]
+			if next < len(payloads) {
+				data = payloads[next]
+				return true
+			}
+			return false
+		})
+		if err != nil {
+			return
+		}
+
+		v, err := decode(data, ch.Type().Elem())
+		if err != nil {
+			return
+		}
+
+		cases[0].Send = v
+		chosen, _, _ := reflect.Select(cases)
+		if chosen != 0 {
+			return
+		}
+	}
+}
+
+// decode unmarshals the payload into a new value of typ, which may be a pointer
+func decode(data []byte, typ reflect.Type) (reflect.Value, error) {
+	if typ.Kind() == reflect.Pointer {
+		v := reflect.New(typ.Elem())
+		return v, json.Unmarshal(data, v.Interface())
+	}
+
+	v := reflect.New(typ)
+	return v.Elem(), json.Unmarshal(data, v.Interface())
+}
+
+// Barrier returns a barrier which fires once at least target entries have signalled the state
+func (c *Client) Barrier(ctx context.Context, state sync.State, target int) (*sync.Barrier, error) {
+	if c.isClosed() {
+		return nil, ErrClosed
+	}
+
+	b := &sync.Barrier{C: make(chan error, 1)}
+	go func() {
+		defer close(b.C)
+		err := c.svc.wait(ctx, c.closed, func() bool {
+			return c.svc.states[state] >= int64(target)
+		})
+		if err == nil {
+			err = c.delay(ctx, c.svc.barrierDelay(state, target))
+		}
+		b.C <- err
+	}()
+	return b, nil
+}
+
+// delay blocks for d, unless ctx is done or the client is closed first
+func (c *Client) delay(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.closed:
+		return ErrClosed
+	}
+}
+
+// SignalEntry increments the state and returns its value after the increment
+func (c *Client) SignalEntry(_ context.Context, state sync.State) (int64, error) {
+	if c.isClosed() {
+		return -1, ErrClosed
+	}
+
+	c.svc.mu.Lock()
+	defer c.svc.mu.Unlock()
+	c.svc.states[state]++
+	c.svc.notify()
+	return c.svc.states[state], nil
+}
+
+// SignalEvent drops the event, there is no daemon collecting them
+func (c *Client) SignalEvent(context.Context, *runtime.Event) error {
+	return nil
+}
+
+// Close ends the subscriptions and barriers of the client
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+	})
+	return nil
+}
+
+// PublishAndWait publishes the payload and waits for the barrier on the state
+func (c *Client) PublishAndWait(
+	ctx context.Context,
+	topic *sync.Topic,
+	payload interface{},
+	state sync.State,
+	target int,
+) (int64, error) {
+	seq, err := c.Publish(ctx, topic, payload)
+	if err != nil {
+		return -1, err
+	}
+
+	b, err := c.Barrier(ctx, state, target)
+	if err != nil {
+		return seq, err
+	}
+	return seq, <-b.C
+}
+
+// PublishSubscribe publishes the payload and subscribes to the same topic
+func (c *Client) PublishSubscribe(
+	ctx context.Context,
+	topic *sync.Topic,
+	payload interface{},
+	ch interface{},
+) (int64, *sync.Subscription, error) {
+	seq, err := c.Publish(ctx, topic, payload)
+	if err != nil {
+		return -1, nil, err
+	}
+
+	sub, err := c.Subscribe(ctx, topic, ch)
+	if err != nil {
+		return seq, nil, err
+	}
+	return seq, sub, nil
+}
+
+// SignalAndWait signals the state and waits until target entries have signalled it
+func (c *Client) SignalAndWait(ctx context.Context, state sync.State, target int) (int64, error) {
+	seq, err := c.SignalEntry(ctx, state)
+	if err != nil {
+		return -1, err
+	}
+
+	b, err := c.Barrier(ctx, state, target)
+	if err != nil {
+		return seq, err
+	}
+	return seq, <-b.C
+}
+
+func (c *Client) MustBarrier(ctx context.Context, state sync.State, target int) *sync.Barrier {
+	b, err := c.Barrier(ctx, state, target)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (c *Client) MustSignalEntry(ctx context.Context, state sync.State) int64 {
+	seq, err := c.SignalEntry(ctx, state)
+	if err != nil {
+		panic(err)
+	}
+	return seq
+}
+
+func (c *Client) MustSubscribe(ctx context.Context, topic *sync.Topic, ch interface{}) *sync.Subscription {
+	sub, err := c.Subscribe(ctx, topic, ch)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
+
+func (c *Client) MustPublish(ctx context.Context, topic *sync.Topic, payload interface{}) int64 {
+	seq, err := c.Publish(ctx, topic, payload)
+	if err != nil {
+		panic(err)
+	}
+	return seq
+}
+
+func (c *Client) MustPublishAndWait(
+	ctx context.Context,
+	topic *sync.Topic,
+	payload interface{},
+	state sync.State,
+	target int,
+) int64 {
+	seq, err := c.PublishAndWait(ctx, topic, payload, state, target)
+	if err != nil {
+		panic(err)
+	}
+	return seq
+}
+
+func (c *Client) MustPublishSubscribe(
+	ctx context.Context,
+	topic *sync.Topic,
+	payload interface{},
+	ch interface{},
+) (int64, *sync.Subscription) {
+	seq, sub, err := c.PublishSubscribe(ctx, topic, payload, ch)
+	if err != nil {
+		panic(err)
+	}
+	return seq, sub
+}
+
+func (c *Client) MustSignalAndWait(ctx context.Context, state sync.State, target int) int64 {
+	seq, err := c.SignalAndWait(ctx, state, target)
+	if err != nil {
+		panic(err)
+	}
+	return seq
+}
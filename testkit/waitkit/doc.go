@@ -0,0 +1,18 @@
+/*
+Package waitkit replaces fixed sleeps with readiness probes
+
+Instead of assuming how long the cluster needs to get an instance ready,
+a probe polls the real condition with an exponential backoff until it is met
+or the timeout of the Config is reached:
+
+- ForHeight polls the tendermint RPC /status until the height is reached
+- ForAppNode waits for the first block and the gRPC port of a validator
+- ForNextBlock waits until the chain advances by at least one block
+- ForTCP, ForRPC and ForGRPC wait until the port accepts connections
+- ForPeer waits until a libp2p host is able to connect to the given peer
+- ForBarrier waits until the sync service counts enough instances in a state
+
+err := waitkit.ForHeight(ctx, net.ParseIP("127.0.0.1"), 1)
+err = waitkit.Until(ctx, waitkit.DefaultConfig, func(ctx context.Context) (bool, error) {...})
+*/
+package waitkit
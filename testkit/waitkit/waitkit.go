@@ -0,0 +1,204 @@
+package waitkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/rpc/jsonrpc/types"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/testground/sdk-go/sync"
+)
+
+// ErrTimeout is returned when a probe doesn't succeed within the Config's timeout
+var ErrTimeout = errors.New("readiness probe timed out")
+
+// Probe reports whether the awaited condition is met. Errors are treated as
+// transient, e.g. a node that is not listening yet, and the probe is retried
+type Probe func(ctx context.Context) (bool, error)
+
+// Config defines how long and how often a probe is retried
+type Config struct {
+	// Timeout bounds the total time spent polling
+	Timeout time.Duration
+	// Interval is the delay before the first retry
+	Interval time.Duration
+	// MaxInterval caps the delay between two retries
+	MaxInterval time.Duration
+	// Factor multiplies the delay after each retry
+	Factor float64
+}
+
+// DefaultConfig is used by all the For* probes
+var DefaultConfig = Config{
+	Timeout:     10 * time.Minute,
+	Interval:    500 * time.Millisecond,
+	MaxInterval: 10 * time.Second,
+	Factor:      1.5,
+}
+
+// Until polls the probe until it succeeds, the context is done or the timeout is reached.
+// On timeout the last error returned by the probe is wrapped into ErrTimeout
+func Until(ctx context.Context, cfg Config, probe Probe) error {
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	interval := cfg.Interval
+	var lastErr error
+	for {
+		ok, err := probe(ctx)
+		if ok && err == nil {
+			return nil
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return fmt.Errorf("%w after %s: %v", ErrTimeout, cfg.Timeout, lastErr)
+			}
+			return fmt.Errorf("%w after %s", ErrTimeout, cfg.Timeout)
+		case <-time.After(interval):
+		}
+
+		interval = time.Duration(float64(interval) * cfg.Factor)
+		if interval > cfg.MaxInterval {
+			interval = cfg.MaxInterval
+		}
+	}
+}
+
+// GetHeight returns the latest block height reported by the RPC /status of the app node
+func GetHeight(ctx context.Context, ip net.IP) (int64, error) {
+	info, err := GetSyncInfo(ctx, ip)
+	if err != nil {
+		return 0, err
+	}
+	return info.LatestBlockHeight, nil
+}
+
+// GetSyncInfo returns the sync info reported by the RPC /status of the app node
+func GetSyncInfo(ctx context.Context, ip net.IP) (*coretypes.SyncInfo, error) {
+	uri := fmt.Sprintf("http://%s:26657/status", ip.To4().String())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var rpcResponse types.RPCResponse
+	if err := rpcResponse.UnmarshalJSON(body); err != nil {
+		return nil, err
+	}
+	if rpcResponse.Error != nil {
+		return nil, rpcResponse.Error
+	}
+
+	var status *coretypes.ResultStatus
+	if err := tmjson.Unmarshal(rpcResponse.Result, &status); err != nil {
+		return nil, err
+	}
+
+	return &status.SyncInfo, nil
+}
+
+// ForHeight waits until the app node reports a block height of at least the given one
+func ForHeight(ctx context.Context, ip net.IP, height int64) error {
+	return Until(ctx, DefaultConfig, func(ctx context.Context) (bool, error) {
+		h, err := GetHeight(ctx, ip)
+		if err != nil {
+			return false, err
+		}
+		return h >= height, nil
+	})
+}
+
+// ForNextBlock waits until the app node produces a new block on top of the current one
+func ForNextBlock(ctx context.Context, ip net.IP) error {
+	var current int64
+	err := Until(ctx, DefaultConfig, func(ctx context.Context) (bool, error) {
+		h, err := GetHeight(ctx, ip)
+		current = h
+		return err == nil, err
+	})
+	if err != nil {
+		return err
+	}
+
+	return ForHeight(ctx, ip, current+1)
+}
+
+// ForTCP waits until the given address accepts tcp connections
+func ForTCP(ctx context.Context, addr string) error {
+	var dialer net.Dialer
+	return Until(ctx, DefaultConfig, func(ctx context.Context) (bool, error) {
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return false, err
+		}
+		return true, conn.Close()
+	})
+}
+
+// ForRPC waits until the RPC port of the app node accepts connections
+func ForRPC(ctx context.Context, ip net.IP) error {
+	return ForTCP(ctx, net.JoinHostPort(ip.To4().String(), "26657"))
+}
+
+// ForGRPC waits until the gRPC port of the app node accepts connections
+func ForGRPC(ctx context.Context, ip net.IP) error {
+	return ForTCP(ctx, net.JoinHostPort(ip.To4().String(), "9090"))
+}
+
+// ForAppNode waits until the app node has produced its first block and serves gRPC,
+// which is all the bridge nodes need to start
+func ForAppNode(ctx context.Context, ip net.IP) error {
+	err := ForHeight(ctx, ip, 1)
+	if err != nil {
+		return err
+	}
+	return ForGRPC(ctx, ip)
+}
+
+// ForPeer waits until the libp2p host is connected to the given peer
+func ForPeer(ctx context.Context, h host.Host, ai peer.AddrInfo) error {
+	return Until(ctx, DefaultConfig, func(ctx context.Context) (bool, error) {
+		err := h.Connect(ctx, ai)
+		return err == nil, err
+	})
+}
+
+// ForBarrier waits until the target amount of instances have signalled the state
+func ForBarrier(ctx context.Context, client sync.Client, state sync.State, target int) error {
+	ctx, cancel := context.WithTimeout(ctx, DefaultConfig.Timeout)
+	defer cancel()
+
+	b, err := client.Barrier(ctx, state, target)
+	if err != nil {
+		return err
+	}
+
+	err = <-b.C
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s: %d instances didn't reach %s", ErrTimeout, DefaultConfig.Timeout, target, state)
+	}
+	return err
+}
@@ -0,0 +1,31 @@
+package appsync
+
+import (
+	"errors"
+
+	"github.com/celestiaorg/test-infra/tests/helpers/common"
+)
+
+// Params of the test-cases made of validators and seeds only,
+// e.g. 001-val-large-txs
+type Params struct {
+	common.Topology
+	common.PeerGraph
+	common.Genesis
+	common.Execution
+	common.Submission
+	// Seed is the amount of seed nodes
+	Seed int `param:"seed" default:"0"`
+}
+
+func (p *Params) Validate() error {
+	return errors.Join(
+		p.Topology.Validate(),
+		p.PeerGraph.Validate(),
+		p.Genesis.Validate(),
+		p.Execution.Validate(),
+		p.Submission.Validate(),
+		common.AtLeast("validator", p.Validator, 1),
+		common.AtLeast("seed", p.Seed, 0),
+	)
+}
@@ -3,15 +3,18 @@ package appsync
 import (
 	"context"
 	"fmt"
-	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"net"
 	"path/filepath"
 	"time"
 
 	"github.com/celestiaorg/test-infra/testkit"
 	"github.com/celestiaorg/test-infra/testkit/appkit"
+	"github.com/celestiaorg/test-infra/testkit/graphkit"
+	"github.com/celestiaorg/test-infra/testkit/netkit"
+	"github.com/celestiaorg/test-infra/testkit/randkit"
+	"github.com/celestiaorg/test-infra/testkit/waitkit"
 	"github.com/celestiaorg/test-infra/tests/helpers/common"
 
-	"github.com/testground/sdk-go/network"
 	"github.com/testground/sdk-go/run"
 	"github.com/testground/sdk-go/runtime"
 )
@@ -22,30 +25,8 @@ func RunSeed(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 	defer cancel()
 
 	syncclient := initCtx.SyncClient
-	netclient := network.NewClient(syncclient, runenv)
 
-	netclient.MustWaitNetworkInitialized(ctx)
-
-	config := network.Config{
-		Network: "default",
-		Enable:  true,
-		Default: network.LinkShape{
-			Latency:   time.Duration(runenv.IntParam("latency")),
-			Bandwidth: common.GetBandwidthValue(runenv.StringParam("bandwidth")),
-		},
-		CallbackState: "network-configured",
-		RoutingPolicy: network.AllowAll,
-	}
-
-	config.IPv4 = runenv.TestSubnet
-
-	// using the assigned `GlobalSequencer` id per each of instance
-	// to fill in the last 2 octets of the new IP address for the instance
-	ipC := byte((initCtx.GlobalSeq >> 8) + 1)
-	ipD := byte(initCtx.GlobalSeq)
-	config.IPv4.IP = append(config.IPv4.IP[0:2:2], ipC, ipD)
-
-	err := netclient.ConfigureNetwork(ctx, &config)
+	config, err := netkit.ConfigureNetwork(ctx, runenv, initCtx)
 	if err != nil {
 		return err
 	}
@@ -63,42 +44,55 @@ func RunSeed(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 	}
 	runenv.RecordMessage("Chain initialised")
 
-	valCh := make(chan *appkit.ValidatorNode)
-	sub, err := syncclient.Subscribe(ctx, testkit.ValidatorPeerTopic, valCh)
+	vals, err := common.GetValidatorPeers(ctx, syncclient, runenv.IntParam("validator"))
+	if err != nil {
+		return err
+	}
+	runenv.RecordMessage("Received %d Validator peers", len(vals))
 
+	// the seeds are the hubs of a star, followed by the validators sorted by node ID
+	seeds := runenv.IntParam("seed")
+	cfg := graphkit.Config{Kind: graphkit.Star, Hubs: seeds, Seed: randkit.Seed(runenv)}
+	g, err := cfg.Generate(seeds + len(vals))
 	if err != nil {
 		return err
 	}
 
-	var peers []appkit.ValidatorNode
-	for i := 0; i < runenv.IntParam("validator"); i++ {
-		select {
-		case err = <-sub.Done():
-			if err != nil {
-				return err
-			}
-		case val := <-valCh:
-			peers = append(peers, *val)
+	self := int(initCtx.GroupSeq) - 1
+	if self == 0 {
+		nodes := make([]string, 0, seeds+len(vals))
+		for i := 0; i < seeds; i++ {
+			nodes = append(nodes, fmt.Sprintf("seed-%d", i+1))
+		}
+		for _, val := range vals {
+			nodes = append(nodes, val.PubKey)
+		}
+
+		err = common.PublishPeerGraph(ctx, runenv, initCtx, cfg, nodes, g)
+		if err != nil {
+			return err
 		}
 	}
-	runenv.RecordMessage("Received %d Validator peers", len(peers))
 
-	randomizer := tmrand.Intn(runenv.IntParam("validator"))
-	peersRange := runenv.IntParam("validator") / runenv.IntParam("seed")
-	randPeers := common.GetRandomisedPeers(randomizer, peersRange, peers)
-	if randPeers == nil {
-		return fmt.Errorf("no peers added for seed's addrbook, got %s", randPeers)
+	var peers []appkit.ValidatorNode
+	for _, i := range g.Peers[self] {
+		if i >= seeds {
+			peers = append(peers, vals[i-seeds])
+		}
+	}
+	if len(peers) == 0 {
+		return fmt.Errorf("no peers added for seed's addrbook")
 	}
 
-	err = appkit.AddPeersToAddressBook(home, randPeers)
+	err = appkit.AddPeersToAddressBook(home, peers)
 	if err != nil {
 		return err
 	}
 
-	runenv.RecordMessage("Added %d to the address book", len(randPeers))
+	runenv.RecordMessage("Added %d to the address book", len(peers))
 
 	ipCh := make(chan *string)
-	sub, err = syncclient.Subscribe(ctx, testkit.CurlGenesisState, ipCh)
+	sub, err := syncclient.Subscribe(ctx, testkit.CurlGenesisState, ipCh)
 	if err != nil {
 		return err
 	}
@@ -111,7 +105,10 @@ func RunSeed(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 		}
 	case ip := <-ipCh:
 		runenv.RecordMessage("curling genesis state from this validator's ip - %s", *ip)
-		time.Sleep(30 * time.Second)
+		err = waitkit.ForRPC(ctx, net.ParseIP(*ip))
+		if err != nil {
+			return err
+		}
 
 		// We need to curl the instance 1 with RPC to get the genesis.json file
 		// Only 1 validator must fire up to provide the RPC
@@ -4,14 +4,16 @@ import (
 	"context"
 	"fmt"
 	"github.com/celestiaorg/test-infra/testkit"
+	"math/rand"
 	"net"
 	"path/filepath"
 	"time"
 
 	"github.com/celestiaorg/test-infra/testkit/appkit"
+	"github.com/celestiaorg/test-infra/testkit/netkit"
+	"github.com/celestiaorg/test-infra/testkit/waitkit"
 	"github.com/celestiaorg/test-infra/tests/helpers/common"
 
-	"github.com/testground/sdk-go/network"
 	"github.com/testground/sdk-go/run"
 	"github.com/testground/sdk-go/runtime"
 )
@@ -21,13 +23,8 @@ func RunValidator(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 	defer cancel()
 
 	syncclient := initCtx.SyncClient
-	netclient := network.NewClient(syncclient, runenv)
 
-	netclient.MustWaitNetworkInitialized(ctx)
-
-	config := CreateConfig(runenv, initCtx)
-
-	err := netclient.ConfigureNetwork(ctx, &config)
+	_, err := netkit.ConfigureNetwork(ctx, runenv, initCtx)
 	if err != nil {
 		return err
 	}
@@ -38,7 +35,7 @@ func RunValidator(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 	}
 
 	if initCtx.GroupSeq == 1 {
-		ip, err := netclient.GetDataNetworkIP()
+		ip, err := initCtx.NetClient.GetDataNetworkIP()
 		if err != nil {
 			return err
 		}
@@ -61,15 +58,18 @@ func RunValidator(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 		go appcmd.StartNode("info")
 	}
 
-	// wait for a new block to be produced
-	time.Sleep(2 * time.Minute)
+	// wait for the first block to be produced and gRPC to be served
+	err = waitkit.ForAppNode(ctx, net.ParseIP("127.0.0.1"))
+	if err != nil {
+		return err
+	}
 
 	_, err = syncclient.SignalAndWait(ctx, testkit.FinishState, runenv.TestInstanceCount)
 	if err != nil {
 		return err
 	}
 
-	err = SubmitPFBs(runenv, appcmd)
+	err = SubmitPFBs(runenv, appcmd, common.InstanceRand(runenv, initCtx, "blobs"))
 	if err != nil {
 		return err
 	}
@@ -77,33 +77,11 @@ func RunValidator(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 	return nil
 }
 
-func CreateConfig(runenv *runtime.RunEnv, initCtx *run.InitContext) network.Config {
-	config := network.Config{
-		Network: "default",
-		Enable:  true,
-		Default: network.LinkShape{
-			Latency:   time.Duration(runenv.IntParam("latency")),
-			Bandwidth: common.GetBandwidthValue(runenv.StringParam("bandwidth")),
-		},
-		CallbackState: "network-configured",
-		RoutingPolicy: network.AllowAll,
-	}
-
-	config.IPv4 = runenv.TestSubnet
-
-	// using the assigned `GlobalSequencer` id per each of instance
-	// to fill in the last 2 octets of the new IP address for the instance
-	ipC := byte((initCtx.GlobalSeq >> 8) + 1)
-	ipD := byte(initCtx.GlobalSeq)
-	config.IPv4.IP = append(config.IPv4.IP[0:2:2], ipC, ipD)
-
-	return config
-}
-
-func SubmitPFBs(runenv *runtime.RunEnv, appcmd *appkit.AppKit) error {
+func SubmitPFBs(runenv *runtime.RunEnv, appcmd *appkit.AppKit, r *rand.Rand) error {
 	for i := 0; i < runenv.IntParam("submit-times"); i++ {
 		runenv.RecordMessage("Submitting PFD with %d bytes random data", runenv.IntParam("msg-size"))
-		err := appcmd.PayForBlob(
+		res, err := appcmd.PayForBlob(
+			r,
 			appcmd.AccountAddress,
 			runenv.IntParam("msg-size"),
 			"test",
@@ -113,6 +91,7 @@ func SubmitPFBs(runenv *runtime.RunEnv, appcmd *appkit.AppKit) error {
 			runenv.RecordFailure(err)
 			return err
 		}
+		res.Record(runenv)
 
 		s, err := appkit.GetLatestsBlockSize(net.ParseIP("127.0.0.1"))
 		if err != nil {
@@ -0,0 +1,54 @@
+package blocksynchistorical
+
+import (
+	"errors"
+
+	"github.com/celestiaorg/test-infra/testkit/paramkit"
+	"github.com/celestiaorg/test-infra/tests/helpers/common"
+	"github.com/testground/sdk-go/runtime"
+)
+
+// Params of the blocksync-historical test-case
+type Params struct {
+	common.Topology
+	common.PeerGraph
+	common.Genesis
+	common.Execution
+	common.Submission
+	common.Assignment
+	common.DANode
+	common.Getter
+	common.Bootstrap
+	InterconnectBridges bool `param:"interconnect-bridges" default:"false"`
+	// Historical is the amount of full nodes syncing the past blocks
+	Historical int `param:"historical"`
+}
+
+func (p *Params) Validate() error {
+	return errors.Join(
+		p.Topology.Validate(),
+		p.PeerGraph.Validate(),
+		p.Genesis.Validate(),
+		p.Execution.Validate(),
+		p.Submission.Validate(),
+		p.Assignment.Validate(),
+		p.DANode.Validate(),
+		p.Getter.Validate(),
+		p.Bootstrap.Validate(),
+		common.AtLeast("validator", p.Validator, 1),
+		common.AtLeast("bridge", p.Bridge, 1),
+		common.AtLeast("historical", p.Historical, 1),
+		common.AtMost("bootstrap-bridges", p.BootstrapBridges, p.Bridge),
+	)
+}
+
+// LoadParams loads the params of the instance, which were already validated
+// by the test-case before dispatching it
+func LoadParams(runenv *runtime.RunEnv) (*Params, error) {
+	p := &Params{}
+	err := paramkit.Load(runenv, p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -3,55 +3,34 @@ package blocksynchistorical
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/celestiaorg/test-infra/testkit"
+	"github.com/celestiaorg/test-infra/testkit/netkit"
 	"github.com/celestiaorg/test-infra/testkit/nodekit"
 	"github.com/celestiaorg/test-infra/tests/helpers/common"
 	"github.com/libp2p/go-libp2p/core/host"
-	"github.com/testground/sdk-go/network"
 	"github.com/testground/sdk-go/run"
 	"github.com/testground/sdk-go/runtime"
 )
 
 func RunBridgeNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
-	ctx, cancel := context.WithTimeout(
-		context.Background(),
-		time.Minute*time.Duration(runenv.IntParam("execution-time")),
-	)
+	p, err := LoadParams(runenv)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.Timeout())
 	defer cancel()
 
-	err := nodekit.SetLoggersLevel("INFO")
+	err = nodekit.SetLoggersLevel("INFO")
 	if err != nil {
 		runenv.RecordFailure(err)
 		return err
 	}
 
 	syncclient := initCtx.SyncClient
-	netclient := network.NewClient(syncclient, runenv)
-
-	netclient.MustWaitNetworkInitialized(ctx)
-
-	config := network.Config{
-		Network: "default",
-		Enable:  true,
-		Default: network.LinkShape{
-			Latency:   time.Duration(runenv.IntParam("latency")),
-			Bandwidth: common.GetBandwidthValue(runenv.StringParam("bandwidth")),
-		},
-		CallbackState: "network-configured",
-		RoutingPolicy: network.AllowAll,
-	}
-
-	config.IPv4 = runenv.TestSubnet
-
-	// using the assigned `GlobalSequencer` id per each of instance
-	// to fill in the last 2 octects of the new IP address for the instance
-	ipC := byte((initCtx.GlobalSeq >> 8) + 1)
-	ipD := byte(initCtx.GlobalSeq)
-	config.IPv4.IP = append(config.IPv4.IP[0:2:2], ipC, ipD)
 
-	err = netclient.ConfigureNetwork(ctx, &config)
+	_, err = netkit.ConfigureNetwork(ctx, runenv, initCtx)
 	if err != nil {
 		return err
 	}
@@ -66,7 +45,7 @@ func RunBridgeNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 		return err
 	}
 
-	l, err := syncclient.Barrier(ctx, testkit.ValidatorReadyTopic, runenv.IntParam("validator"))
+	l, err := syncclient.Barrier(ctx, testkit.ValidatorReadyTopic, p.Validator)
 	if err != nil {
 		return err
 	}
@@ -75,9 +54,9 @@ func RunBridgeNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 		return lerr
 	}
 
-	if runenv.BooleanParam("interconnect-bridges") {
+	if p.InterconnectBridges {
 		runenv.RecordMessage("Connecting to other bridge nodes")
-		bridgeNodes, _ := common.GetBridgeNodes(ctx, syncclient, runenv.IntParam("bridge"))
+		bridgeNodes, _ := common.GetBridgeNodes(ctx, syncclient, p.Bridge)
 		for _, bridge := range bridgeNodes {
 			if bridge.AddrInfo.ID != host.InfoFromHost(nd.Host).ID {
 				nd.Host.Connect(ctx, bridge.AddrInfo)
@@ -86,7 +65,7 @@ func RunBridgeNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 		}
 	}
 
-	for i := 0; i < runenv.IntParam("block-height"); i++ {
+	for i := 0; i < p.BlockHeight; i++ {
 		// After reaching a dedicated block-height, we can signal other node types
 		// to start syncing the past
 		eh, err := nd.HeaderServ.GetByHeight(ctx, uint64(i+1))
@@ -95,7 +74,7 @@ func RunBridgeNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 		}
 		runenv.RecordMessage(
 			"Reached Block#%d contains Hash: %s",
-			runenv.IntParam("block-height"),
+			p.BlockHeight,
 			eh.Commit.BlockID.Hash.String(),
 		)
 	}
@@ -116,7 +95,7 @@ func RunBridgeNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 	l, err = syncclient.Barrier(
 		ctx,
 		testkit.FinishState,
-		runenv.IntParam("historicals"),
+		p.Historical,
 	)
 	if err != nil {
 		return err
@@ -3,82 +3,40 @@ package blocksynchistorical
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/test-infra/testkit"
+	"github.com/celestiaorg/test-infra/testkit/netkit"
 	"github.com/celestiaorg/test-infra/testkit/nodekit"
 	"github.com/celestiaorg/test-infra/tests/helpers/common"
-	"github.com/testground/sdk-go/network"
 	"github.com/testground/sdk-go/run"
 	"github.com/testground/sdk-go/runtime"
 )
 
 func RunFullNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
-	ctx, cancel := context.WithTimeout(
-		context.Background(),
-		time.Minute*time.Duration(runenv.IntParam("execution-time")),
-	)
-	defer cancel()
-
-	err := nodekit.SetLoggersLevel("DEBUG")
+	p, err := LoadParams(runenv)
 	if err != nil {
 		return err
 	}
 
-	syncclient := initCtx.SyncClient
-	netclient := network.NewClient(syncclient, runenv)
-
-	netclient.MustWaitNetworkInitialized(ctx)
+	ctx, cancel := context.WithTimeout(context.Background(), p.Timeout())
+	defer cancel()
 
-	config := network.Config{
-		Network: "default",
-		Enable:  true,
-		Default: network.LinkShape{
-			Latency:   time.Duration(runenv.IntParam("latency")),
-			Bandwidth: common.GetBandwidthValue(runenv.StringParam("bandwidth")),
-		},
-		CallbackState: "network-configured",
-		RoutingPolicy: network.AllowAll,
+	err = nodekit.SetLoggersLevel("DEBUG")
+	if err != nil {
+		return err
 	}
 
-	config.IPv4 = runenv.TestSubnet
-
-	// using the assigned `GlobalSequencer` id per each of instance
-	// to fill in the last 2 octets of the new IP address for the instance
-	ipC := byte((initCtx.GlobalSeq >> 8) + 1)
-	ipD := byte(initCtx.GlobalSeq)
-	config.IPv4.IP = append(config.IPv4.IP[0:2:2], ipC, ipD)
+	syncclient := initCtx.SyncClient
 
-	err = netclient.ConfigureNetwork(ctx, &config)
+	_, err = netkit.ConfigureNetwork(ctx, runenv, initCtx)
 	if err != nil {
 		return err
 	}
 
-	bridgeNode := &testkit.BridgeNodeInfo{}
-	trustedPeers := []string{}
-	if runenv.BooleanParam("multibootstrap") {
-		bridgeNodes, err := common.GetBridgeNodes(ctx, syncclient, runenv.IntParam("bridge"))
-		if err != nil {
-			return err
-		}
-
-		for _, bridge := range bridgeNodes {
-			if (int(initCtx.GroupSeq) % runenv.IntParam("bridge")) == (bridge.ID % runenv.IntParam("bridge")) {
-				bridgeNode = bridge
-			}
-		}
-
-		trustedPeers := []string{}
-		for _, bridge := range bridgeNodes {
-			trustedPeers = append(trustedPeers, bridge.Maddr)
-		}
-	} else {
-		bridgeNode, err = common.GetBridgeNode(ctx, syncclient, initCtx.GroupSeq, runenv.IntParam("bridge"))
-		if err != nil {
-			return err
-		}
-		trustedPeers = []string{bridgeNode.Maddr}
+	bridgeNode, trustedPeers, err := common.TrustedPeers(ctx, runenv, initCtx, p.Bridge)
+	if err != nil {
+		return err
 	}
 
 	ndhome := fmt.Sprintf("/.celestia-full-%d", initCtx.GlobalSeq)
@@ -91,14 +49,7 @@ func RunFullNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 
 	cfg := nodekit.NewConfig(node.Full, ip, trustedPeers, bridgeNode.TrustedHash)
 
-	switch runenv.StringParam("getter") {
-	case "shrex":
-		cfg.Share.UseShareExchange = true
-	case "ipld":
-		fallthrough
-	default:
-		cfg.Share.UseShareExchange = false
-	}
+	cfg.Share.UseShareExchange = p.UseShareExchange()
 
 	nd, err := nodekit.NewNode(
 		ndhome,
@@ -116,15 +67,25 @@ func RunFullNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 		return err
 	}
 
+	err = common.PublishFullNode(ctx, runenv, initCtx, nd)
+	if err != nil {
+		return err
+	}
+
+	err = common.CheckBootstrap(ctx, runenv, nd, trustedPeers)
+	if err != nil {
+		return err
+	}
+
 	runenv.RecordMessage("Full node is syncing")
-	eh, err := nd.HeaderServ.GetByHeight(ctx, uint64(runenv.IntParam("block-height")))
+	eh, err := nd.HeaderServ.GetByHeight(ctx, uint64(p.BlockHeight))
 	if err != nil {
 		return err
 	}
 
 	runenv.RecordMessage(
 		"Reached Block#%d contains Hash: %s",
-		runenv.IntParam("block-height"),
+		p.BlockHeight,
 		eh.Commit.BlockID.Hash.String(),
 	)
 
@@ -136,7 +97,7 @@ func RunFullNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 		return fmt.Errorf("full node is still syncing the past")
 	}
 
-	l, err := syncclient.Barrier(ctx, testkit.FinishState, runenv.IntParam("historical"))
+	l, err := syncclient.Barrier(ctx, testkit.FinishState, p.Historical)
 	if err != nil {
 		return err
 	}
@@ -3,49 +3,29 @@ package blocksynchistorical
 import (
 	"context"
 	"net"
-	"time"
 
 	"github.com/celestiaorg/test-infra/testkit/appkit"
 	"github.com/celestiaorg/test-infra/tests/helpers/common"
 
 	"github.com/celestiaorg/test-infra/testkit"
-	"github.com/testground/sdk-go/network"
+	"github.com/celestiaorg/test-infra/testkit/netkit"
+	"github.com/celestiaorg/test-infra/testkit/waitkit"
 	"github.com/testground/sdk-go/run"
 	"github.com/testground/sdk-go/runtime"
 )
 
 func RunValidator(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
-	ctx, cancel := context.WithTimeout(
-		context.Background(),
-		time.Minute*time.Duration(runenv.IntParam("execution-time")),
-	)
-	defer cancel()
-
-	syncclient := initCtx.SyncClient
-	netclient := network.NewClient(syncclient, runenv)
-
-	netclient.MustWaitNetworkInitialized(ctx)
-
-	config := network.Config{
-		Network: "default",
-		Enable:  true,
-		Default: network.LinkShape{
-			Latency:   time.Duration(runenv.IntParam("latency")),
-			Bandwidth: common.GetBandwidthValue(runenv.StringParam("bandwidth")),
-		},
-		CallbackState: "network-configured",
-		RoutingPolicy: network.AllowAll,
+	p, err := LoadParams(runenv)
+	if err != nil {
+		return err
 	}
 
-	config.IPv4 = runenv.TestSubnet
+	ctx, cancel := context.WithTimeout(context.Background(), p.Timeout())
+	defer cancel()
 
-	// using the assigned `GlobalSequencer` id per each of instance
-	// to fill in the last 2 octects of the new IP address for the instance
-	ipC := byte((initCtx.GlobalSeq >> 8) + 1)
-	ipD := byte(initCtx.GlobalSeq)
-	config.IPv4.IP = append(config.IPv4.IP[0:2:2], ipC, ipD)
+	syncclient := initCtx.SyncClient
 
-	err := netclient.ConfigureNetwork(ctx, &config)
+	_, err = netkit.ConfigureNetwork(ctx, runenv, initCtx)
 	if err != nil {
 		return err
 	}
@@ -65,9 +45,11 @@ func RunValidator(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 	runenv.RecordMessage("Blocksync: Validator starting...")
 	go appcmd.StartNode("info")
 
-	// wait for a new block to be produced
-	// RPC is also being initialized...
-	time.Sleep(1 * time.Minute)
+	// wait for the first block to be produced and gRPC to be served
+	err = waitkit.ForAppNode(ctx, net.ParseIP("127.0.0.1"))
+	if err != nil {
+		return err
+	}
 
 	runenv.RecordMessage("Publishing app-validator address")
 
@@ -80,8 +62,9 @@ func RunValidator(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 		ctx,
 		testkit.AppNodeTopic,
 		&testkit.AppNodeInfo{
-			ID: int(initCtx.GroupSeq),
-			IP: ip,
+			ID:   int(initCtx.GroupSeq),
+			IP:   ip,
+			Zone: common.InstanceZone(runenv),
 		},
 	)
 
@@ -90,9 +73,12 @@ func RunValidator(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 	}
 
 	// wait for a new block to be produced
-	time.Sleep(1 * time.Minute)
+	err = waitkit.ForNextBlock(ctx, net.ParseIP("127.0.0.1"))
+	if err != nil {
+		return err
+	}
 
-	l, err := syncclient.Barrier(ctx, testkit.BridgeStartedState, runenv.IntParam("bridge"))
+	l, err := syncclient.Barrier(ctx, testkit.BridgeStartedState, p.Bridge)
 	if err != nil {
 		return err
 	}
@@ -101,10 +87,11 @@ func RunValidator(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 		return err
 	}
 
+	r := common.InstanceRand(runenv, initCtx, "blobs")
 	l, err = syncclient.Barrier(
 		ctx,
 		testkit.FinishState,
-		runenv.IntParam("full")+runenv.IntParam("historical")+runenv.IntParam("bridge"),
+		p.Full+p.Historical+p.Bridge,
 	)
 	if err != nil {
 		return err
@@ -121,18 +108,20 @@ func RunValidator(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 			return nil
 
 		default:
-			runenv.RecordMessage("Submitting PFD with %d bytes random data", runenv.IntParam("msg-size"))
-			err = appcmd.PayForBlob(
+			runenv.RecordMessage("Submitting PFD with %d bytes random data", p.MsgSize)
+			res, err := appcmd.PayForBlob(
+				r,
 				appcmd.AccountAddress,
-				runenv.IntParam("msg-size"),
+				p.MsgSize,
 				"test",
 				appcmd.GetHomePath(),
 			)
 			if err != nil {
 				return err
 			}
+			res.Record(runenv)
 
-			_, _, err := appkit.GetLatestBlockSizeAndHeight(net.ParseIP("127.0.0.1"))
+			_, _, err = appkit.GetLatestBlockSizeAndHeight(net.ParseIP("127.0.0.1"))
 			if err != nil {
 				runenv.RecordMessage("err in last size call, %s", err.Error())
 			}
@@ -0,0 +1,174 @@
+package byzantine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+
+	"github.com/celestiaorg/test-infra/testkit"
+	"github.com/celestiaorg/test-infra/testkit/byzkit"
+	"github.com/celestiaorg/test-infra/testkit/nodekit"
+	"github.com/celestiaorg/test-infra/testkit/waitkit"
+	"github.com/celestiaorg/test-infra/tests/helpers/common"
+)
+
+// outcome is how a full or light node handled an attacked height
+type outcome string
+
+const (
+	// failed is a height the DASer failed to sample
+	failed outcome = "failed"
+	// sampled is a height the DASer sampled successfully
+	sampled outcome = "sampled"
+)
+
+// expected is the outcome an honest node of the type has to reach for the attack
+func expected(attack byzkit.Attack, tp node.Type) outcome {
+	// the full nodes get the original shares over shrex and compute the proofs themselves
+	if attack == byzkit.CorruptProofs && tp == node.Full {
+		return sampled
+	}
+	return failed
+}
+
+// detection is the outcome of an attacked height, written to the detections.json output
+type detection struct {
+	Height   uint64  `json:"height"`
+	Outcome  outcome `json:"outcome"`
+	Expected outcome `json:"expected"`
+	// Delay is the time from the header of the height until the outcome
+	Delay time.Duration `json:"delay"`
+}
+
+// awaitDetection waits for the outcome of every height attacked by the byzantine bridges
+// on the node and fails if any of them is not the expected one. The node signals the
+// ByzantineDetectedState in any case, so the bridges don't keep waiting for it
+func awaitDetection(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	initCtx *run.InitContext,
+	nd *nodebuilder.Node,
+	p *Params,
+) error {
+	err := checkDetections(ctx, runenv, initCtx, nd, p)
+	_, signalErr := initCtx.SyncClient.SignalEntry(ctx, testkit.ByzantineDetectedState)
+	return errors.Join(err, signalErr)
+}
+
+func checkDetections(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	initCtx *run.InitContext,
+	nd *nodebuilder.Node,
+	p *Params,
+) error {
+	cfg, err := p.Byzantine.Config()
+	if err != nil {
+		return err
+	}
+
+	heights, err := getAttackedHeights(ctx, initCtx, p.ByzantineBridges)
+	if err != nil {
+		return err
+	}
+
+	want := expected(cfg.Attack, nd.Type)
+	detections := make([]detection, 0, len(heights))
+	var errs []error
+	for _, h := range heights {
+		d, err := detect(ctx, nd, h, want, p.DetectionDeadline)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("height %d: %w", h, err))
+			continue
+		}
+		if d.Outcome != want {
+			errs = append(errs, fmt.Errorf("height %d attacked with %s: expected %s, got %s", h, cfg.Attack, want, d.Outcome))
+		}
+
+		runenv.R().Counter(fmt.Sprintf("byzantine.%s", d.Outcome)).Inc(1)
+		runenv.R().RecordPoint("byzantine.detection_ms", float64(d.Delay.Milliseconds()))
+		runenv.RecordMessage("Attacked height %d is %s after %s, expected %s", h, d.Outcome, d.Delay, want)
+		detections = append(detections, *d)
+	}
+
+	f, err := runenv.CreateRawAsset("detections.json")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = json.NewEncoder(f).Encode(detections)
+	return errors.Join(append(errs, err)...)
+}
+
+// detect waits for the DASer of the node to be done with the height, which either
+// failed to sample it or sampled it successfully
+func detect(
+	ctx context.Context,
+	nd *nodebuilder.Node,
+	height uint64,
+	want outcome,
+	deadline time.Duration,
+) (*detection, error) {
+	ctx, cancel := context.WithTimeout(ctx, deadline)
+	defer cancel()
+
+	_, err := nd.HeaderServ.GetByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+
+	d := &detection{Height: height, Expected: want}
+	cfg := waitkit.DefaultConfig
+	cfg.Timeout = deadline
+	err = waitkit.Until(ctx, cfg, func(ctx context.Context) (bool, error) {
+		done, hasFailed, err := nodekit.SamplingOutcome(ctx, nd, height)
+		if err != nil || !done {
+			return false, err
+		}
+		d.Outcome = sampled
+		if hasFailed {
+			d.Outcome = failed
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.Delay = time.Since(start)
+	return d, nil
+}
+
+// getAttackedHeights returns the sorted heights attacked by all the byzantine bridges
+func getAttackedHeights(ctx context.Context, initCtx *run.InitContext, bridges int) ([]uint64, error) {
+	reports, err := common.GetByzantineReports(ctx, initCtx.SyncClient, bridges)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		heights  []uint64
+		attacked = make(map[uint64]bool)
+	)
+	for _, r := range reports {
+		for _, h := range r.Heights {
+			if !attacked[h] {
+				attacked[h] = true
+				heights = append(heights, h)
+			}
+		}
+	}
+
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights, nil
+}
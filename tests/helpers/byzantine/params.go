@@ -0,0 +1,33 @@
+package byzantine
+
+import (
+	"errors"
+
+	"github.com/celestiaorg/test-infra/tests/helpers/common"
+)
+
+// Params of the test-cases where full and light nodes sample from byzantine
+// bridges, e.g. byzantine-bridge
+type Params struct {
+	common.Topology
+	common.PeerGraph
+	common.Genesis
+	common.Execution
+	common.Submission
+	common.Assignment
+	common.Byzantine
+}
+
+func (p *Params) Validate() error {
+	return errors.Join(
+		p.Topology.Validate(),
+		p.PeerGraph.Validate(),
+		p.Genesis.Validate(),
+		p.Execution.Validate(),
+		p.Submission.Validate(),
+		p.Assignment.Validate(),
+		p.Byzantine.Validate(),
+		common.AtLeast("validator", p.Validator, 1),
+		common.AtLeast("bridge", p.Bridge, p.ByzantineBridges),
+	)
+}
@@ -0,0 +1,124 @@
+package byzantine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+
+	"github.com/celestiaorg/test-infra/testkit"
+	"github.com/celestiaorg/test-infra/testkit/byzkit"
+	"github.com/celestiaorg/test-infra/testkit/netkit"
+	"github.com/celestiaorg/test-infra/testkit/nodekit"
+	"github.com/celestiaorg/test-infra/testkit/paramkit"
+	"github.com/celestiaorg/test-infra/testkit/randkit"
+	"github.com/celestiaorg/test-infra/tests/helpers/common"
+)
+
+// RunBridgeNode runs a byzantine bridge for the first byzantine-bridges instances
+// and an honest one otherwise. The bridges keep serving until all the full and
+// light nodes are done with the attacked heights
+func RunBridgeNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		time.Minute*time.Duration(runenv.IntParam("execution-time")),
+	)
+	defer cancel()
+
+	err := nodekit.SetLoggersLevel("INFO")
+	if err != nil {
+		return err
+	}
+
+	syncclient := initCtx.SyncClient
+
+	_, err = netkit.ConfigureNetwork(ctx, runenv, initCtx)
+	if err != nil {
+		return err
+	}
+
+	var p Params
+	err = paramkit.Load(runenv, &p)
+	if err != nil {
+		return err
+	}
+
+	var nd *nodebuilder.Node
+	if p.IsByzantine(initCtx.GroupSeq) {
+		nd, err = runByzantineBridge(ctx, runenv, initCtx, &p.Byzantine)
+	} else {
+		nd, err = common.BuildBridge(ctx, runenv, initCtx)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = <-syncclient.MustBarrier(ctx, testkit.ByzantineDetectedState, p.Full+p.Light).C
+	if err != nil {
+		return err
+	}
+
+	err = nd.Stop(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = syncclient.SignalEntry(ctx, testkit.FinishState)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// runByzantineBridge builds the byzantine bridge and publishes the heights it attacked
+// once it's past the last height of the attack
+func runByzantineBridge(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	initCtx *run.InitContext,
+	p *common.Byzantine,
+) (*nodebuilder.Node, error) {
+	cfg, err := p.Config()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Seed = randkit.Seed(runenv)
+
+	byz, err := byzkit.NewBridge(runenv, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	nd, err := common.BuildBridge(ctx, runenv, initCtx, byz.Options())
+	if err != nil {
+		return nil, err
+	}
+	runenv.RecordMessage("Byzantine bridge %d attacks with %s at heights %s", initCtx.GroupSeq, cfg.Attack, p.AttackHeights)
+
+	last := cfg.Heights[len(cfg.Heights)-1]
+	_, err = nd.HeaderServ.GetByHeight(ctx, last)
+	if err != nil {
+		return nil, err
+	}
+
+	attacked := byz.Attacked()
+	if len(attacked) == 0 {
+		return nil, fmt.Errorf("none of the attack-heights %s had data, submit blobs before them", p.AttackHeights)
+	}
+	runenv.R().RecordPoint("byzantine.attacked_heights", float64(len(attacked)))
+
+	_, err = initCtx.SyncClient.Publish(ctx, testkit.ByzantineReportTopic, &testkit.ByzantineReport{
+		GlobalSeq: initCtx.GlobalSeq,
+		Attack:    string(cfg.Attack),
+		Heights:   attacked,
+		Squares:   byz.Squares(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nd, nil
+}
@@ -0,0 +1,87 @@
+package byzantine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+
+	"github.com/celestiaorg/test-infra/testkit"
+	"github.com/celestiaorg/test-infra/testkit/netkit"
+	"github.com/celestiaorg/test-infra/testkit/nodekit"
+	"github.com/celestiaorg/test-infra/testkit/paramkit"
+	"github.com/celestiaorg/test-infra/tests/helpers/common"
+)
+
+// RunFullNode runs a full node trusting its assigned bridge, which checks
+// the heights attacked by the byzantine bridges, see awaitDetection
+func RunFullNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		time.Minute*time.Duration(runenv.IntParam("execution-time")),
+	)
+	defer cancel()
+
+	err := nodekit.SetLoggersLevel("INFO")
+	if err != nil {
+		return err
+	}
+
+	syncclient := initCtx.SyncClient
+
+	_, err = netkit.ConfigureNetwork(ctx, runenv, initCtx)
+	if err != nil {
+		return err
+	}
+
+	var p Params
+	err = paramkit.Load(runenv, &p)
+	if err != nil {
+		return err
+	}
+
+	bridgeNode, err := common.AssignBridge(ctx, runenv, initCtx, p.Bridge)
+	if err != nil {
+		return err
+	}
+
+	ndhome := fmt.Sprintf("/.celestia-full-%d", initCtx.GlobalSeq)
+	runenv.RecordMessage(ndhome)
+
+	ip, err := initCtx.NetClient.GetDataNetworkIP()
+	if err != nil {
+		return err
+	}
+
+	trustedPeers := []string{bridgeNode.Maddr}
+	cfg := nodekit.NewConfig(node.Full, ip, trustedPeers, bridgeNode.TrustedHash)
+	nd, err := nodekit.NewNode(ndhome, node.Full, runenv.StringParam("p2p-network"), cfg)
+	if err != nil {
+		return err
+	}
+
+	err = nd.Start(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = awaitDetection(ctx, runenv, initCtx, nd, &p)
+	if err != nil {
+		return err
+	}
+
+	err = nd.Stop(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = syncclient.SignalEntry(ctx, testkit.FinishState)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/celestiaorg/test-infra/testkit"
+	"github.com/celestiaorg/test-infra/testkit/assignkit"
+	"github.com/celestiaorg/test-infra/testkit/paramkit"
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+	"github.com/testground/sdk-go/sync"
+)
+
+// assignment is recorded in the outputs of the instance, so the topology of a run
+// can be rebuilt from the outputs of all its instances
+type assignment struct {
+	Kind     string `json:"kind"`
+	Strategy string `json:"strategy"`
+	Group    string `json:"group"`
+	Client   int    `json:"client"`
+	Zone     string `json:"zone,omitempty"`
+	Servers  []int  `json:"servers"`
+	Seed     int64  `json:"seed"`
+}
+
+// InstanceZone returns the zone param of the instance, which is published along
+// its address to let the others assign it by locality
+func InstanceZone(runenv *runtime.RunEnv) string {
+	if runenv.IsParamSet("zone") {
+		return runenv.StringParam("zone")
+	}
+	return ""
+}
+
+// GetValidators waits for the given amount of validators to publish their address
+func GetValidators(ctx context.Context, syncclient sync.Client, valAmount int) ([]*testkit.AppNodeInfo, error) {
+	appInfoCh := make(chan *testkit.AppNodeInfo, valAmount)
+	sub, err := syncclient.Subscribe(ctx, testkit.AppNodeTopic, appInfoCh)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		validators []*testkit.AppNodeInfo
+		seen       = make(map[int]bool)
+	)
+	for len(validators) < valAmount {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("received %d out of %d validator addresses: %w", len(validators), valAmount, ctx.Err())
+		case err = <-sub.Done():
+			if err != nil {
+				return nil, fmt.Errorf("no app has been sent for this node to connect to remotely")
+			}
+		case appInfo := <-appInfoCh:
+			if !seen[appInfo.ID] {
+				seen[appInfo.ID] = true
+				validators = append(validators, appInfo)
+			}
+		}
+	}
+	return validators, nil
+}
+
+// AssignValidator waits for the validators and returns the one the instance connects to,
+// according to the validator-assignment param. A node only connects to a single validator,
+// so all-to-all gives the first one
+func AssignValidator(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	initCtx *run.InitContext,
+	valAmount int,
+) (*testkit.AppNodeInfo, error) {
+	var a Assignment
+	err := paramkit.Load(runenv, &a)
+	if err != nil {
+		return nil, err
+	}
+
+	validators, err := GetValidators(ctx, initCtx.SyncClient, valAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	servers := make([]assignkit.Node, len(validators))
+	byID := make(map[int]*testkit.AppNodeInfo, len(validators))
+	for i, v := range validators {
+		servers[i] = assignkit.Node{ID: v.ID, Zone: v.Zone}
+		byID[v.ID] = v
+	}
+
+	cfg := a.ValidatorConfig()
+	client := assignkit.Node{ID: int(initCtx.GroupSeq), Zone: a.Zone}
+	ids, err := cfg.Assign(client, servers)
+	if err != nil {
+		return nil, err
+	}
+
+	err = recordAssignment(runenv, "validator", cfg, client, ids)
+	if err != nil {
+		return nil, err
+	}
+	return byID[ids[0]], nil
+}
+
+// AssignBridges waits for the bridges and returns the ones the instance connects to,
+// according to the bridge-assignment param
+func AssignBridges(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	initCtx *run.InitContext,
+	amountOfBridges int,
+) ([]*testkit.BridgeNodeInfo, error) {
+	bridges, err := GetBridgeNodes(ctx, initCtx.SyncClient, amountOfBridges)
+	if err != nil {
+		return nil, err
+	}
+	return AssignedBridges(runenv, initCtx, bridges)
+}
+
+// AssignBridge is AssignBridges for the nodes connecting to a single bridge, the first assigned one
+func AssignBridge(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	initCtx *run.InitContext,
+	amountOfBridges int,
+) (*testkit.BridgeNodeInfo, error) {
+	bridges, err := AssignBridges(ctx, runenv, initCtx, amountOfBridges)
+	if err != nil {
+		return nil, err
+	}
+	return bridges[0], nil
+}
+
+// AssignedBridges returns the bridges the instance connects to among the given ones,
+// according to the bridge-assignment param
+func AssignedBridges(
+	runenv *runtime.RunEnv,
+	initCtx *run.InitContext,
+	bridges []*testkit.BridgeNodeInfo,
+) ([]*testkit.BridgeNodeInfo, error) {
+	var a Assignment
+	err := paramkit.Load(runenv, &a)
+	if err != nil {
+		return nil, err
+	}
+
+	servers := make([]assignkit.Node, len(bridges))
+	byID := make(map[int]*testkit.BridgeNodeInfo, len(bridges))
+	for i, b := range bridges {
+		servers[i] = assignkit.Node{ID: b.ID, Zone: b.Zone}
+		byID[b.ID] = b
+	}
+
+	cfg := a.BridgeConfig()
+	client := assignkit.Node{ID: int(initCtx.GroupSeq), Zone: a.Zone}
+	ids, err := cfg.Assign(client, servers)
+	if err != nil {
+		return nil, err
+	}
+
+	err = recordAssignment(runenv, "bridge", cfg, client, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	assigned := make([]*testkit.BridgeNodeInfo, len(ids))
+	for i, id := range ids {
+		assigned[i] = byID[id]
+	}
+	return assigned, nil
+}
+
+// recordAssignment logs the assignment and writes it to assignment-<kind>.json in the outputs of the instance
+func recordAssignment(runenv *runtime.RunEnv, kind string, cfg assignkit.Config, client assignkit.Node, ids []int) error {
+	runenv.RecordMessage("%s %d in zone %q assigned to %s %v by %s", runenv.TestGroupID, client.ID, client.Zone, kind, ids, cfg.Strategy)
+
+	f, err := runenv.CreateRawAsset(fmt.Sprintf("assignment-%s.json", kind))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return json.NewEncoder(f).Encode(assignment{
+		Kind:     kind,
+		Strategy: string(cfg.Strategy),
+		Group:    runenv.TestGroupID,
+		Client:   client.ID,
+		Zone:     client.Zone,
+		Servers:  ids,
+		Seed:     cfg.Seed,
+	})
+}
@@ -0,0 +1,100 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/celestiaorg/test-infra/testkit"
+	"github.com/celestiaorg/test-infra/testkit/synckit"
+)
+
+// publishValidators publishes the address of the amount of validators, numbered from 1
+func publishValidators(t *testing.T, c syncCase, svc *synckit.Service, amount int) {
+	ctx := c.ctx(t)
+	for i := 1; i <= amount; i++ {
+		_, initCtx := newInstance(t, svc, "validators", int64(i), nil)
+		go publishWhenReady(ctx, t, initCtx.SyncClient, amount, testkit.AppNodeTopic, &testkit.AppNodeInfo{
+			ID: i,
+			IP: net.IPv4(10, 0, 1, byte(i)),
+		})
+	}
+}
+
+func TestGetValidators(t *testing.T) {
+	const validators = 3
+	for _, c := range syncCases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			svc := synckit.NewServiceWithFaults(c.faults)
+			publishValidators(t, c, svc, validators)
+
+			_, initCtx := newInstance(t, svc, "bridges", 1, nil)
+			got, err := GetValidators(c.ctx(t), initCtx.SyncClient, validators)
+			c.check(t, err)
+			if c.err != nil {
+				return
+			}
+
+			ids := make([]int, len(got))
+			for i, v := range got {
+				ids[i] = v.ID
+				if want := net.IPv4(10, 0, 1, byte(v.ID)); !v.IP.Equal(want) {
+					t.Errorf("validator %d: expected the IP %s, got %s", v.ID, want, v.IP)
+				}
+			}
+			sort.Ints(ids)
+			if fmt.Sprint(ids) != "[1 2 3]" {
+				t.Errorf("expected the validators [1 2 3] once, got %v", ids)
+			}
+		})
+	}
+}
+
+func TestAssignValidator(t *testing.T) {
+	const (
+		validators = 3
+		bridges    = 4
+	)
+	for _, c := range syncCases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			svc := synckit.NewServiceWithFaults(c.faults)
+			publishValidators(t, c, svc, validators)
+
+			// the bridges are assigned round-robin whatever the order the validators are received in
+			for seq := 1; seq <= bridges; seq++ {
+				runenv, initCtx := newInstance(t, svc, "bridges", int64(seq), map[string]string{
+					"validator-assignment": "round-robin",
+				})
+				got, err := AssignValidator(c.ctx(t), runenv, initCtx, validators)
+				c.check(t, err)
+				if c.err != nil {
+					return
+				}
+
+				want := (seq-1)%validators + 1
+				if got.ID != want {
+					t.Errorf("bridge %d: expected validator %d, got %d", seq, want, got.ID)
+				}
+
+				bt, err := os.ReadFile(filepath.Join(runenv.TestOutputsPath, "assignment-validator.json"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				var a assignment
+				err = json.Unmarshal(bt, &a)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if a.Client != seq || len(a.Servers) != 1 || a.Servers[0] != want {
+					t.Errorf("bridge %d: unexpected recorded assignment %+v", seq, a)
+				}
+			}
+		})
+	}
+}
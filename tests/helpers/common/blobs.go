@@ -0,0 +1,153 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	appns "github.com/celestiaorg/celestia-app/pkg/namespace"
+	"github.com/celestiaorg/celestia-node/blob"
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/test-infra/testkit/appkit"
+	"github.com/testground/sdk-go/runtime"
+)
+
+// SubmitBlobs pays for all the given blobs in a single PFB submitted by the node
+// and records the result of the included tx as metrics
+func SubmitBlobs(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	nd *nodebuilder.Node,
+	blobs ...*blob.Blob,
+) (*appkit.PayForBlobResult, error) {
+	strategy, err := appkit.TxStrategyFromParams(runenv, DefaultSubmitStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := make([]int, len(blobs))
+	for i, b := range blobs {
+		sizes[i] = len(b.Data)
+	}
+
+	// the node can't simulate txs, so the simulate strategy falls back to the blob size estimate
+	gasLimit, fee, err := strategy.Estimate(ctx, appkit.GasRequest{BlobSizes: sizes})
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	tx, err := nd.StateServ.SubmitPayForBlob(ctx, fee, gasLimit, blobs)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.Code != 0 {
+		runenv.RecordMessage("pfb %s failed with code %d: %s", tx.TxHash, tx.Code, tx.RawLog)
+		return nil, fmt.Errorf("failed pfd")
+	}
+
+	res := &appkit.PayForBlobResult{
+		TxHash:    tx.TxHash,
+		Height:    tx.Height,
+		GasWanted: tx.GasWanted,
+		GasUsed:   tx.GasUsed,
+		Fee:       fmt.Sprintf("%sutia", fee),
+		BlobSizes: sizes,
+		Latency:   time.Since(start),
+	}
+	res.Record(runenv)
+	return res, nil
+}
+
+// RandomBlobs builds the blobs of the specs with random data in random namespaces
+func RandomBlobs(r *rand.Rand, specs []appkit.BlobSpec) ([]*blob.Blob, error) {
+	namespaces := make(map[int]share.Namespace)
+	blobs := make([]*blob.Blob, 0, len(specs))
+	for _, spec := range specs {
+		ns, ok := namespaces[spec.Namespace]
+		if !ok {
+			id := make([]byte, appns.NamespaceVersionZeroIDSize)
+			r.Read(id)
+
+			var err error
+			ns, err = share.NewBlobNamespaceV0(id)
+			if err != nil {
+				return nil, err
+			}
+			namespaces[spec.Namespace] = ns
+		}
+
+		data := make([]byte, spec.Size)
+		r.Read(data)
+
+		b, err := blob.NewBlobV0(ns, data)
+		if err != nil {
+			return nil, err
+		}
+		blobs = append(blobs, b)
+	}
+	return blobs, nil
+}
+
+// VerifyBlobs checks that every blob landed in its namespace at the given height
+// with the exact data that was submitted
+func VerifyBlobs(ctx context.Context, nd *nodebuilder.Node, height uint64, blobs ...*blob.Blob) error {
+	for _, b := range blobs {
+		got, err := nd.BlobServ.Get(ctx, height, b.Namespace(), b.Commitment)
+		if err != nil {
+			return fmt.Errorf("blob %X not found in namespace %s at height %d: %w", b.Commitment, b.Namespace(), height, err)
+		}
+
+		if !bytes.Equal(got.Data, b.Data) {
+			return fmt.Errorf("blob %X in namespace %s at height %d has different data", b.Commitment, b.Namespace(), height)
+		}
+	}
+	return nil
+}
+
+// SubmitAndVerifyBlobs submits the blobs of the plan in a single PFB and verifies
+// each of them against its namespace at the inclusion height
+func SubmitAndVerifyBlobs(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	nd *nodebuilder.Node,
+	plan appkit.BlobPlan,
+	r *rand.Rand,
+) (*appkit.PayForBlobResult, error) {
+	blobs, err := RandomBlobs(r, plan.Specs(r))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := SubmitBlobs(ctx, runenv, nd, blobs...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = VerifyBlobs(ctx, nd, uint64(res.Height), blobs...)
+	if err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// SubmitPlannedBlobs reads the blob plan from the composition params and submits
+// `submit-times` PFBs following it, verifying every blob after its inclusion
+func SubmitPlannedBlobs(ctx context.Context, runenv *runtime.RunEnv, nd *nodebuilder.Node, r *rand.Rand) error {
+	plan, err := appkit.BlobPlanFromParams(runenv)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < runenv.IntParam("submit-times"); i++ {
+		_, err = SubmitAndVerifyBlobs(ctx, runenv, nd, plan, r)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
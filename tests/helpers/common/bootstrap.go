@@ -0,0 +1,233 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+
+	"github.com/celestiaorg/test-infra/testkit"
+	"github.com/celestiaorg/test-infra/testkit/paramkit"
+	"github.com/celestiaorg/test-infra/testkit/waitkit"
+)
+
+// bootstrapTimeout bounds the wait for the connections to the trusted peers
+const bootstrapTimeout = 2 * time.Minute
+
+// TrustedPeers waits for the bridges and returns the addresses a full node trusts,
+// along the bridge it takes the trusted hash from, according to the bootstrap params.
+// Without multibootstrap these are its assigned bridges. With multibootstrap these are
+// bootstrap-bridges of all the bridges, starting with its assigned one, and the
+// bootstrap-fulls full nodes of its group started right before it
+func TrustedPeers(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	initCtx *run.InitContext,
+	amountOfBridges int,
+) (*testkit.BridgeNodeInfo, []string, error) {
+	var b Bootstrap
+	err := paramkit.Load(runenv, &b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bridges, err := GetBridgeNodes(ctx, initCtx.SyncClient, amountOfBridges)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	assigned, err := AssignedBridges(runenv, initCtx, bridges)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	trusted := assigned
+	if b.Multibootstrap {
+		trusted = bootstrapBridges(assigned[0], bridges, b.BootstrapBridges)
+	}
+
+	var trustedPeers []string
+	for _, bridge := range trusted {
+		trustedPeers = append(trustedPeers, bridge.Maddr)
+	}
+
+	if b.Multibootstrap && b.BootstrapFulls > 0 {
+		fulls, err := getPreviousFullNodes(ctx, runenv, initCtx, b.BootstrapFulls)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, full := range fulls {
+			trustedPeers = append(trustedPeers, full.Maddr)
+		}
+	}
+
+	runenv.RecordMessage("Full %d trusts %d peers: %v", initCtx.GroupSeq, len(trustedPeers), trustedPeers)
+	return assigned[0], trustedPeers, nil
+}
+
+// bootstrapBridges returns amount of the bridges sorted by ID, starting from the first
+// one and wrapping around, all of them if amount is 0
+func bootstrapBridges(first *testkit.BridgeNodeInfo, bridges []*testkit.BridgeNodeInfo, amount int) []*testkit.BridgeNodeInfo {
+	sorted := make([]*testkit.BridgeNodeInfo, len(bridges))
+	copy(sorted, bridges)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	if amount == 0 || amount > len(sorted) {
+		amount = len(sorted)
+	}
+
+	start := 0
+	for i, bridge := range sorted {
+		if bridge.ID == first.ID {
+			start = i
+		}
+	}
+
+	trusted := make([]*testkit.BridgeNodeInfo, amount)
+	for i := range trusted {
+		trusted[i] = sorted[(start+i)%len(sorted)]
+	}
+	return trusted
+}
+
+// getPreviousFullNodes waits for the amount full nodes of the group with the sequence
+// numbers right before the one of the instance, the first ones of the group get less of them
+func getPreviousFullNodes(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	initCtx *run.InitContext,
+	amount int,
+) ([]*testkit.FullNodeInfo, error) {
+	seq := int(initCtx.GroupSeq)
+	wanted := make(map[int]bool)
+	for id := seq - amount; id < seq; id++ {
+		if id >= 1 {
+			wanted[id] = true
+		}
+	}
+	if len(wanted) == 0 {
+		return nil, nil
+	}
+
+	fullCh := make(chan *testkit.FullNodeInfo)
+	sub, err := initCtx.SyncClient.Subscribe(ctx, testkit.FullNodeTopic, fullCh)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		fulls []*testkit.FullNodeInfo
+		seen  = make(map[int]bool)
+	)
+	for len(fulls) < len(wanted) {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("received %d out of %d full node addresses: %w", len(fulls), len(wanted), ctx.Err())
+		case err = <-sub.Done():
+			if err != nil {
+				return nil, fmt.Errorf("no full node has been sent for this node to trust: %w", err)
+			}
+		case full := <-fullCh:
+			if full.Group == runenv.TestGroupID && wanted[full.ID] && !seen[full.ID] {
+				seen[full.ID] = true
+				fulls = append(fulls, full)
+			}
+		}
+	}
+	return fulls, nil
+}
+
+// PublishFullNode publishes the address of the started full node for the next full nodes
+// of its group to trust it, which is only needed with bootstrap-fulls
+func PublishFullNode(ctx context.Context, runenv *runtime.RunEnv, initCtx *run.InitContext, nd *nodebuilder.Node) error {
+	var b Bootstrap
+	err := paramkit.Load(runenv, &b)
+	if err != nil {
+		return err
+	}
+	if !b.Multibootstrap || b.BootstrapFulls == 0 {
+		return nil
+	}
+
+	addrs, err := peer.AddrInfoToP2pAddrs(host.InfoFromHost(nd.Host))
+	if err != nil {
+		return err
+	}
+
+	_, err = initCtx.SyncClient.Publish(
+		ctx,
+		testkit.FullNodeTopic,
+		&testkit.FullNodeInfo{
+			ID:    int(initCtx.GroupSeq),
+			Maddr: addrs[0].String(),
+			Group: runenv.TestGroupID,
+		},
+	)
+	return err
+}
+
+// CheckBootstrap compares the peers the node is connected to with its trusted peers and
+// records the difference. With check-bootstrap, it waits for the node to connect to all
+// its trusted peers and fails if it doesn't
+func CheckBootstrap(ctx context.Context, runenv *runtime.RunEnv, nd *nodebuilder.Node, trustedPeers []string) error {
+	var b Bootstrap
+	err := paramkit.Load(runenv, &b)
+	if err != nil {
+		return err
+	}
+
+	trusted := make([]peer.ID, 0, len(trustedPeers))
+	for _, maddr := range trustedPeers {
+		ai, err := peer.AddrInfoFromString(maddr)
+		if err != nil {
+			return fmt.Errorf("invalid trusted peer %s: %w", maddr, err)
+		}
+		trusted = append(trusted, ai.ID)
+	}
+
+	missing := func() []peer.ID {
+		var ids []peer.ID
+		for _, id := range trusted {
+			if nd.Host.Network().Connectedness(id) != network.Connected {
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}
+
+	if b.CheckBootstrap {
+		cfg := waitkit.DefaultConfig
+		cfg.Timeout = bootstrapTimeout
+		err = waitkit.Until(ctx, cfg, func(context.Context) (bool, error) {
+			if ids := missing(); len(ids) > 0 {
+				return false, fmt.Errorf("not connected to trusted peers %v", ids)
+			}
+			return true, nil
+		})
+	}
+
+	isTrusted := make(map[peer.ID]bool, len(trusted))
+	for _, id := range trusted {
+		isTrusted[id] = true
+	}
+	var extra []peer.ID
+	for _, id := range nd.Host.Network().Peers() {
+		if !isTrusted[id] {
+			extra = append(extra, id)
+		}
+	}
+
+	notConnected := missing()
+	runenv.RecordMessage(
+		"Connected to %d out of %d trusted peers, missing: %v, other peers: %v",
+		len(trusted)-len(notConnected), len(trusted), notConnected, extra,
+	)
+	return err
+}
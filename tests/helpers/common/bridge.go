@@ -17,10 +17,26 @@ import (
 
 	"github.com/celestiaorg/test-infra/testkit"
 	"github.com/celestiaorg/test-infra/testkit/appkit"
+	"github.com/celestiaorg/test-infra/testkit/faultkit"
 	"github.com/celestiaorg/test-infra/testkit/nodekit"
 )
 
 func BuildBridge(ctx context.Context, runenv *runtime.RunEnv, initCtx *run.InitContext, opts ...fx.Option) (*nodebuilder.Node, error) {
+	target, err := BuildRestartableBridge(ctx, runenv, initCtx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return target.Node(), nil
+}
+
+// BuildRestartableBridge is BuildBridge returning the fault target of the bridge,
+// which rebuilds the bridge with the same config when it is restarted
+func BuildRestartableBridge(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	initCtx *run.InitContext,
+	opts ...fx.Option,
+) (*faultkit.Node, error) {
 	syncclient := initCtx.SyncClient
 
 	err := <-syncclient.MustBarrier(ctx, testkit.ValidatorReadyTopic, runenv.IntParam("validator")).C
@@ -28,7 +44,7 @@ func BuildBridge(ctx context.Context, runenv *runtime.RunEnv, initCtx *run.InitC
 		return nil, err
 	}
 
-	appNode, err := GetValidatorInfo(ctx, syncclient, runenv.IntParam("validator"), int(initCtx.GroupSeq))
+	appNode, err := AssignValidator(ctx, runenv, initCtx, runenv.IntParam("validator"))
 	if err != nil {
 		return nil, err
 	}
@@ -60,20 +76,27 @@ func BuildBridge(ctx context.Context, runenv *runtime.RunEnv, initCtx *run.InitC
 	cfg.Gateway.Port = "26659"
 	cfg.Share.Discovery.PeersLimit = uint(runenv.IntParam("peers-limit"))
 
+	err = UsePrefundedKey(runenv, initCtx, ndhome, cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	optlOpts := []otlpmetrichttp.Option{
 		otlpmetrichttp.WithEndpoint(runenv.StringParam("otel-collector-address")),
 		otlpmetrichttp.WithInsecure(),
 	}
-	nd, err := nodekit.NewNode(ndhome, node.Bridge, runenv.StringParam("p2p-network"), cfg,
-		nodebuilder.WithMetrics(
-			optlOpts,
-			node.Bridge,
-		))
-
+	opts = append(opts, nodebuilder.WithMetrics(
+		optlOpts,
+		node.Bridge,
+	))
+	target, err := faultkit.NewNode(func() (*nodebuilder.Node, nodebuilder.Store, error) {
+		return nodekit.OpenNode(ndhome, node.Bridge, runenv.StringParam("p2p-network"), cfg, opts...)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	nd := target.Node()
 	err = nd.Start(ctx)
 	if err != nil {
 		return nil, err
@@ -104,6 +127,7 @@ func BuildBridge(ctx context.Context, runenv *runtime.RunEnv, initCtx *run.InitC
 			Maddr:       addrs[0].String(),
 			TrustedHash: h,
 			AddrInfo:    *bridgeAddrInfo,
+			Zone:        InstanceZone(runenv),
 		},
 	)
 	if err != nil {
@@ -112,33 +136,7 @@ func BuildBridge(ctx context.Context, runenv *runtime.RunEnv, initCtx *run.InitC
 
 	runenv.RecordMessage("Finished published bridgeID Addr %d", int(initCtx.GroupSeq))
 
-	return nd, nil
-}
-
-func GetBridgeNode(ctx context.Context, syncclient sync.Client, id int64, amountOfBridges int) (*testkit.BridgeNodeInfo, error) {
-	bridgeCh := make(chan *testkit.BridgeNodeInfo, amountOfBridges)
-	sub, err := syncclient.Subscribe(ctx, testkit.BridgeNodeTopic, bridgeCh)
-	if err != nil {
-		return nil, err
-	}
-
-	for {
-		select {
-		case err = <-sub.Done():
-			if err != nil {
-				return nil,
-					fmt.Errorf("no bridge address has been sent to this light node to connect to")
-			}
-		case bridge := <-bridgeCh:
-			fmt.Printf("Received Bridge ID = %d", bridge.ID)
-			if amountOfBridges == 1 {
-				return bridge, nil
-			}
-			if (int(id) % amountOfBridges) == (bridge.ID % amountOfBridges) {
-				return bridge, nil
-			}
-		}
-	}
+	return target, nil
 }
 
 func GetBridgeNodes(
@@ -156,15 +154,22 @@ func GetBridgeNodes(
 		return nil, err
 	}
 
-	for i := 0; i < amountOfBridges; i++ {
+	// a bridge published twice is only returned once
+	seen := make(map[int]bool)
+	for len(bridges) < amountOfBridges {
 		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("received %d out of %d bridge addresses: %w", len(bridges), amountOfBridges, ctx.Err())
 		case err = <-sub.Done():
 			if err != nil {
 				return nil, fmt.Errorf("no bridge address has been sent to this light node to connect to")
 			}
 		case b := <-bridgeCh:
 			fmt.Printf("Received Bridge ID = %d", b.ID)
-			bridges = append(bridges, b)
+			if !seen[b.ID] {
+				seen[b.ID] = true
+				bridges = append(bridges, b)
+			}
 		}
 	}
 
@@ -0,0 +1,47 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/celestiaorg/test-infra/testkit"
+	"github.com/celestiaorg/test-infra/testkit/synckit"
+)
+
+func TestGetBridgeNodes(t *testing.T) {
+	const bridges = 3
+	for _, c := range syncCases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			svc := synckit.NewServiceWithFaults(c.faults)
+			ctx := c.ctx(t)
+			for i := 1; i <= bridges; i++ {
+				_, initCtx := newInstance(t, svc, "bridges", int64(i), nil)
+				go publishWhenReady(ctx, t, initCtx.SyncClient, bridges, testkit.BridgeNodeTopic, &testkit.BridgeNodeInfo{
+					ID:    i,
+					Maddr: fmt.Sprintf("/ip4/10.0.0.%d/tcp/2121", i),
+				})
+			}
+
+			_, initCtx := newInstance(t, svc, "lights", 1, nil)
+			got, err := GetBridgeNodes(ctx, initCtx.SyncClient, bridges)
+			c.check(t, err)
+			if c.err != nil {
+				return
+			}
+
+			ids := make([]int, len(got))
+			for i, b := range got {
+				ids[i] = b.ID
+				if want := fmt.Sprintf("/ip4/10.0.0.%d/tcp/2121", b.ID); b.Maddr != want {
+					t.Errorf("bridge %d: expected the address %s, got %s", b.ID, want, b.Maddr)
+				}
+			}
+			sort.Ints(ids)
+			if fmt.Sprint(ids) != "[1 2 3]" {
+				t.Errorf("expected the bridges [1 2 3] once, got %v", ids)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/testground/sdk-go/sync"
+
+	"github.com/celestiaorg/test-infra/testkit"
+)
+
+// GetByzantineReports waits for the reports of the heights attacked by all the byzantine bridges
+func GetByzantineReports(
+	ctx context.Context,
+	syncclient sync.Client,
+	bridges int,
+) ([]*testkit.ByzantineReport, error) {
+	reportCh := make(chan *testkit.ByzantineReport)
+	sub, err := syncclient.Subscribe(ctx, testkit.ByzantineReportTopic, reportCh)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		reports []*testkit.ByzantineReport
+		seen    = make(map[int64]bool)
+	)
+	for len(reports) < bridges {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("received %d out of %d byzantine reports: %w", len(reports), bridges, ctx.Err())
+		case err = <-sub.Done():
+			if err != nil {
+				return nil, err
+			}
+		case r := <-reportCh:
+			if !seen[r.GlobalSeq] {
+				seen[r.GlobalSeq] = true
+				reports = append(reports, r)
+			}
+		}
+	}
+	return reports, nil
+}
@@ -0,0 +1,77 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+
+	"github.com/celestiaorg/test-infra/testkit/appkit"
+	"github.com/celestiaorg/test-infra/testkit/faultkit"
+	"github.com/celestiaorg/test-infra/testkit/paramkit"
+)
+
+// CrashValidator runs the crash schedule of the chaos params on the validator started
+// in this instance, if the instance is selected to crash.
+// Keep in mind the chain halts while the validators down hold a third of the stake
+func CrashValidator(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	initCtx *run.InitContext,
+	app *appkit.AppKit,
+) error {
+	target := &faultkit.Validator{App: app, LogLevel: "info", IP: net.ParseIP("127.0.0.1")}
+	return runCrashes(ctx, runenv, initCtx, "validator", target)
+}
+
+// CrashNode runs the crash schedule of the chaos params on the node of the role, if the
+// instance is selected to crash, and returns the node running after the last restart
+func CrashNode(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	initCtx *run.InitContext,
+	role string,
+	target *faultkit.Node,
+) (*nodebuilder.Node, error) {
+	err := runCrashes(ctx, runenv, initCtx, role, target)
+	return target.Node(), err
+}
+
+func runCrashes(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	initCtx *run.InitContext,
+	role string,
+	target faultkit.Target,
+) error {
+	var c Chaos
+	err := paramkit.Load(runenv, &c)
+	if err != nil {
+		return err
+	}
+	if !c.Crashes(role, initCtx.GroupSeq) {
+		return nil
+	}
+
+	sch, err := c.Schedule()
+	if err != nil {
+		return err
+	}
+	runenv.RecordMessage("%s %d crashes on schedule %s", role, initCtx.GroupSeq, c.CrashSchedule)
+
+	results, err := faultkit.Run(ctx, runenv, target, sch)
+	if err != nil {
+		return err
+	}
+
+	f, err := runenv.CreateRawAsset("faults.json")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return json.NewEncoder(f).Encode(results)
+}
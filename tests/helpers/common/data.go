@@ -3,30 +3,41 @@ package common
 import (
 	"bytes"
 	"context"
-	"cosmossdk.io/math"
+	"errors"
 	"fmt"
+	"math/rand"
+
 	"github.com/celestiaorg/celestia-node/blob"
 	"github.com/celestiaorg/celestia-node/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/state"
 	"github.com/celestiaorg/nmt/namespace"
-	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/celestiaorg/test-infra/testkit/appkit"
 	"github.com/testground/sdk-go/runtime"
 )
 
+// DefaultInclusionWindow is the amount of blocks after the expected height
+// in which submitted data is still looked for
+const DefaultInclusionWindow = 5
+
+// ErrDataNotFound is returned when the submitted data is not in the namespace of a block
+var ErrDataNotFound = errors.New("data not found")
+
 // DefaultNameId is used in cases where we only have 1 Namespace.ID used
 // across all nodes that submit pfd and get shares by this ID
 var DefaultNameId = namespace.ID{100, 100, 150, 150, 200, 200, 250, 255}
 
-// TODO(@Bidon15): We need to start testing gas mechanism sooner than later
-const gasLimit uint64 = 2000000
+// DefaultSubmitStrategy is the gas limit and fee used by SubmitData unless the
+// composition selects a different strategy
+var DefaultSubmitStrategy = appkit.FixedStrategy(2000000, 30000)
 
 // GetRandomNamespace returns a random namespace.ID per each call made by
 // each instance of node type
-func GetRandomNamespace() namespace.ID {
+func GetRandomNamespace(r *rand.Rand) namespace.ID {
 	for {
-		s := tmrand.Bytes(8)
+		s := make([]byte, 8)
+		r.Read(s)
 		if bytes.Compare(s, share.MaxPrimaryReservedNamespace) > 0 {
 			return s
 		}
@@ -35,81 +46,112 @@ func GetRandomNamespace() namespace.ID {
 
 // GenerateNamespaceID returns a namespace ID based on runenv.StringParams defined in the composition file
 // TODO(@Bidon15): We actually need to refactor this out using runenv.IntParam()
-func GenerateNamespaceID(amount string) namespace.ID {
+func GenerateNamespaceID(r *rand.Rand, amount string) namespace.ID {
 	if amount == "1" {
 		return DefaultNameId
 	}
-	return GetRandomNamespace()
+	return GetRandomNamespace(r)
 }
 
 // GetRandomMessageBySize returns a random []byte per each call made by
 // each instance of node type. The size is defined in the .toml file
-func GetRandomMessageBySize(size int) []byte {
-	return tmrand.Bytes(size)
+func GetRandomMessageBySize(r *rand.Rand, size int) []byte {
+	data := make([]byte, size)
+	r.Read(data)
+	return data
 }
 
-// SubmitData calls a node.StateService SubmitPayForBlob() method with recording a txLog output.
-func SubmitData(ctx context.Context, runenv *runtime.RunEnv, nd *nodebuilder.Node, nid namespace.ID, data []byte) error {
-	fee := math.NewInt(30000)
+// SubmitData calls a node.StateService SubmitPayForBlob() method with a single blob
+// and records the result of the included tx as metrics
+func SubmitData(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	nd *nodebuilder.Node,
+	nid namespace.ID,
+	data []byte,
+) (*appkit.PayForBlobResult, error) {
 	blb, err := blob.NewBlobV0(share.Namespace(nid), data)
 	if err != nil {
-		return err
-	}
-
-	tx, err := nd.StateServ.SubmitPayForBlob(
-		ctx,
-		fee,
-		gasLimit,
-		[]*blob.Blob{blb},
-	)
-	if err != nil {
-		return err
+		return nil, err
 	}
 
-	runenv.RecordMessage("code response is %d", tx.Code)
-	runenv.RecordMessage(tx.RawLog)
-	if tx.Code != 0 {
-		return fmt.Errorf("failed pfd")
-	}
-	return nil
+	return SubmitBlobs(ctx, runenv, nd, blb)
 }
 
 // CheckSharesByNamespace accepts an expected namespace.ID and data that was submitted.
-// Next, it verifies the data against the received shares of a block from a user-specified extended header
-// by comparing length of each
+// Next, it gets the shares of the namespace from a user-specified extended header,
+// validates their NMT inclusion proofs against the DAH, reconstructs the blobs out of
+// them and looks for a blob carrying exactly the expected data
 func CheckSharesByNamespace(ctx context.Context, nd *nodebuilder.Node, nid namespace.ID, eh *header.ExtendedHeader, expectedData []byte) error {
-	shares, err := nd.ShareServ.GetSharesByNamespace(ctx, eh.DAH, share.Namespace(nid))
+	ns := share.Namespace(nid)
+	shares, err := nd.ShareServ.GetSharesByNamespace(ctx, eh.DAH, ns)
 	if err != nil {
 		return err
 	}
 
-	if len(shares) >= len(expectedData) {
-		return nil
+	err = shares.Verify(eh.DAH, ns)
+	if err != nil {
+		return fmt.Errorf("invalid inclusion proof of the shares of namespace %s at height %d: %w", ns, eh.Height(), err)
 	}
 
-	return fmt.Errorf("expected data is not in the shares slice")
-}
+	flattened := shares.Flatten()
+	if len(flattened) == 0 {
+		return fmt.Errorf("%w: no shares in namespace %s at height %d", ErrDataNotFound, ns, eh.Height())
+	}
 
-// VerifyDataInNamespace encapsulates 3 steps to get the data verified against the next block's shares
-// found in a user-specified namespace.ID
-func VerifyDataInNamespace(ctx context.Context, nd *nodebuilder.Node, nid namespace.ID, data []byte) error {
-	eh, err := nd.HeaderServ.NetworkHead(ctx)
+	blobs, err := blob.SharesToBlobs(flattened)
 	if err != nil {
-		return err
+		return fmt.Errorf("reconstructing blobs of namespace %s at height %d: %w", ns, eh.Height(), err)
 	}
 
-	waitH := eh.Height()
-	waitH++
-	eh, err = nd.HeaderServ.GetByHeight(ctx, uint64(waitH))
-	if err != nil {
-		return err
+	for _, b := range blobs {
+		if bytes.Equal(b.Data, expectedData) {
+			return nil
+		}
 	}
 
-	err = CheckSharesByNamespace(ctx, nd, nid, eh, data)
-	if err != nil {
-		return err
+	return fmt.Errorf(
+		"%w: expected data is not in any of the %d blobs of namespace %s at height %d",
+		ErrDataNotFound, len(blobs), ns, eh.Height(),
+	)
+}
+
+// VerifyDataInNamespace looks for the data in a user-specified namespace.ID, starting at the given height
+// and up to window blocks after it. The height is usually the inclusion height of the PFB response;
+// when it is 0, the search starts at the current network head. It returns the height the data was found at
+func VerifyDataInNamespace(
+	ctx context.Context,
+	nd *nodebuilder.Node,
+	nid namespace.ID,
+	data []byte,
+	height uint64,
+	window int,
+) (uint64, error) {
+	if height == 0 {
+		eh, err := nd.HeaderServ.NetworkHead(ctx)
+		if err != nil {
+			return 0, err
+		}
+		height = eh.Height()
 	}
-	return nil
+
+	var lastErr error
+	for h := height; h <= height+uint64(window); h++ {
+		eh, err := nd.HeaderServ.GetByHeight(ctx, h)
+		if err != nil {
+			return 0, err
+		}
+
+		lastErr = CheckSharesByNamespace(ctx, nd, nid, eh, data)
+		if lastErr == nil {
+			return h, nil
+		}
+		if !errors.Is(lastErr, ErrDataNotFound) {
+			return 0, lastErr
+		}
+	}
+
+	return 0, fmt.Errorf("not found within %d blocks after height %d: %w", window, height, lastErr)
 }
 
 // CheckBalanceDeduction checks if the balance of a node has been deducted after a successful pfb
@@ -1,9 +1,8 @@
 /*
-Package common is a helper around redundant creation of Network, App and Node part
+Package common is a helper around redundant creation of App and Node part
 
-As testground doesn't have native support of uint64 conversion from .toml files,
-GetBandwidthValue in network.go is used to adjust the bandwidth per instance
-for each of the test-case
+The network of each instance is configured by testkit/netkit, which reads
+the latency and bandwidth params of the test-case
 
 In order to eliminate the boilerplate code of creating a validators' set,
 please use `common.BuildValidator`. This Func does:
@@ -14,22 +13,78 @@ please use `common.BuildValidator`. This Func does:
 In addition, the func returns initialized cobra cmd, so you can continue
 operating with the validator
 
-	Default: network.LinkShape{
-		Bandwidth: common.GetBandwidthValue(runenv.StringParam("bandwidth")),
-	}
+The persistent peers of every validator are its neighbours in the graph selected by
+the peer-graph param (random-regular, ring, small-world, full-mesh or star, see
+testkit/graphkit), of degree persistent-peers. The graph is published to the
+peer-graph topic and written to the peer-graph.json output of the first validator
 
+The genesis is customized by the params of `common.Genesis`: max-block-bytes, time-iota,
+max-square-size and unbonding-time are written by the first validator before it shares
+the genesis, min-gas-price goes to the app.toml of every validator and stake-distribution
+(equal, linear, geometric or whale) decides the self-delegation of every validator.
+With prefund-da-nodes, the bridge, full and light nodes calling `common.UsePrefundedKey`
+start with an account funded in the genesis
+
+_, err := netkit.ConfigureNetwork(ctx, runenv, initCtx)
 appcmd, err := common.BuildValidator(ctx, runenv, initCtx)
 appcmd.PayForBlob(...)
 
 In order to eliminate the boilerplate code of creating a bridge node,
 please use `common.BuildBridge`. This Func does:
 - Listens to all available validators
-- Connects to the validator (match is done using group sequence)
+- Connects to the validator assigned by the validator-assignment param
 - Sends the genesis hash to the topic
 - - As well as it's multiaddress
 - Returns the node pointer itself
 
 nd, err := common.BuildBridge(ctx, runenv, initCtx)
 nd.Stop()
+
+The full and light nodes find the bridges they connect to with `common.AssignBridge`,
+which applies the bridge-assignment param (round-robin, random, locality, fan-in or
+all-to-all, see testkit/assignkit). Every assignment is logged and written to the
+assignment-bridge.json and assignment-validator.json outputs of the instance
+
+bridge, err := common.AssignBridge(ctx, runenv, initCtx, runenv.IntParam("bridge"))
+
+The full nodes of the block-sync test-cases get their trusted peers from `common.TrustedPeers`.
+With multibootstrap they trust bootstrap-bridges bridges (all by default) and the
+bootstrap-fulls full nodes of their group started right before them, which are published
+by `common.PublishFullNode`. `common.CheckBootstrap` records whether the started node is
+connected to all its trusted peers, and fails it with check-bootstrap
+
+bridge, trustedPeers, err := common.TrustedPeers(ctx, runenv, initCtx, runenv.IntParam("bridge"))
+
+The nodes of the roles in crash-roles are crashed and restarted on the crash-schedule by
+`common.CrashValidator` and `common.CrashNode` (see testkit/faultkit), which fail the instance
+if it doesn't resume syncing within the recovery-deadline. Only the first crash-instances
+instances of every role crash, and their results are written to the faults.json output
+
+target, err := common.BuildRestartableBridge(ctx, runenv, initCtx)
+nd, err := common.CrashNode(ctx, runenv, initCtx, "bridge", target)
+
+With the partition param the instances are cut off into groups at the partition-height
+by `common.PartitionValidator` and `common.RunPartition` (see netkit.ParsePartition).
+The partition heals after the partition-duration, and every instance then has to sync
+past the highest instance within the heal-deadline. The time to heal and the divergence
+of the heights, sampled every second of the partition and at the heal, are recorded and
+written to the partition.json output
+
+err = common.RunPartition(ctx, runenv, initCtx, "bridge", target)
+
+The first byzantine-bridges bridges of the byzantine test-cases attack the attack-heights
+with the attack of `common.Byzantine` (see testkit/byzkit). The withhold attack withholds
+the withhold-fraction of the squares, and the full and light nodes get the reports of all
+the byzantine bridges to know which heights and squares were attacked
+
+cfg, err := params.Byzantine.Config()
+reports, err := common.GetByzantineReports(ctx, initCtx.SyncClient, params.ByzantineBridges)
+
+The light nodes poll the SamplingStats of their DASer every sampling-stats-interval with
+`common.CollectSampling` (see testkit/daskit) and record the summary of the progress to
+the sampling.json output once stopped with `common.StopSampling`
+
+sampling, err := common.CollectSampling(ctx, runenv, nd.DASer.SamplingStats)
+err = common.StopSampling(ctx, runenv, sampling)
 */
 package common
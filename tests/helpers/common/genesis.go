@@ -0,0 +1,69 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+
+	"github.com/celestiaorg/test-infra/testkit/appkit"
+	"github.com/celestiaorg/test-infra/testkit/nodekit"
+	"github.com/celestiaorg/test-infra/testkit/paramkit"
+	"github.com/celestiaorg/test-infra/testkit/randkit"
+)
+
+// prefundedAccName is the name of the funded account in the keyring of the DA nodes
+const prefundedAccName = "prefunded"
+
+// genesisAccounts are the accounts of the validators and, with prefund-da-nodes, the accounts
+// of all the instances. The validators don't know the global sequence numbers of the DA nodes,
+// so every instance of the run gets its account funded
+func genesisAccounts(runenv *runtime.RunEnv, gen *Genesis, validators []string) ([]appkit.GenesisAccount, error) {
+	accs := make([]appkit.GenesisAccount, 0, len(validators))
+	for _, v := range validators {
+		accs = append(accs, appkit.GenesisAccount{Address: v, Amount: gen.ValidatorBalance})
+	}
+	if !gen.PrefundDANodes {
+		return accs, nil
+	}
+
+	seed := randkit.Seed(runenv)
+	for seq := 1; seq <= runenv.TestInstanceCount; seq++ {
+		addr, err := appkit.PrefundedAddress(seed, int64(seq))
+		if err != nil {
+			return nil, err
+		}
+		accs = append(accs, appkit.GenesisAccount{Address: addr, Amount: gen.DANodeBalance})
+	}
+	return accs, nil
+}
+
+// UsePrefundedKey imports the account funded in the genesis into the keyring of the DA node
+// at home and makes the node use it, if the prefund-da-nodes param is set.
+// It has to be called before nodekit.NewNode
+func UsePrefundedKey(runenv *runtime.RunEnv, initCtx *run.InitContext, home string, cfg *nodebuilder.Config) error {
+	var gen Genesis
+	err := paramkit.Load(runenv, &gen)
+	if err != nil {
+		return err
+	}
+	if !gen.PrefundDANodes {
+		return nil
+	}
+
+	seed := randkit.Seed(runenv)
+	addr, err := appkit.PrefundedAddress(seed, initCtx.GlobalSeq)
+	if err != nil {
+		return err
+	}
+
+	cfg.State.KeyringAccName = prefundedAccName
+	err = nodekit.ImportKey(home, cfg, appkit.PrefundedKey(seed, initCtx.GlobalSeq))
+	if err != nil {
+		return fmt.Errorf("importing the prefunded key: %w", err)
+	}
+
+	runenv.RecordMessage("Using the prefunded account %s", addr)
+	return nil
+}
@@ -0,0 +1,426 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/celestiaorg/test-infra/testkit/appkit"
+	"github.com/celestiaorg/test-infra/testkit/assignkit"
+	"github.com/celestiaorg/test-infra/testkit/byzkit"
+	"github.com/celestiaorg/test-infra/testkit/faultkit"
+	"github.com/celestiaorg/test-infra/testkit/graphkit"
+	"github.com/celestiaorg/test-infra/testkit/netkit"
+)
+
+// Topology is the amount of instances of every node type of a test-case.
+// The test-cases requiring a node type check its amount in their own params
+type Topology struct {
+	Validator       int `param:"validator" default:"0"`
+	PersistentPeers int `param:"persistent-peers" default:"0"`
+	Bridge          int `param:"bridge" default:"0"`
+	Full            int `param:"full" default:"0"`
+	Light           int `param:"light" default:"0"`
+}
+
+func (t *Topology) Validate() error {
+	errs := []error{
+		AtLeast("validator", t.Validator, 0),
+		AtLeast("persistent-peers", t.PersistentPeers, 0),
+		AtLeast("bridge", t.Bridge, 0),
+		AtLeast("full", t.Full, 0),
+		AtLeast("light", t.Light, 0),
+	}
+	// a single validator doesn't discover any peer, so persistent-peers is ignored
+	if t.Validator > 1 && t.PersistentPeers >= t.Validator {
+		errs = append(errs, fmt.Errorf(
+			"persistent-peers must be < validator, got %d persistent peers for %d validators",
+			t.PersistentPeers, t.Validator,
+		))
+	}
+	return errors.Join(errs...)
+}
+
+// Execution bounds the duration of an instance
+type Execution struct {
+	// ExecutionTime is in minutes
+	ExecutionTime int `param:"execution-time"`
+}
+
+func (e *Execution) Validate() error {
+	return AtLeast("execution-time", e.ExecutionTime, 1)
+}
+
+// Timeout is the execution time as a duration
+func (e *Execution) Timeout() time.Duration {
+	return time.Minute * time.Duration(e.ExecutionTime)
+}
+
+// Submission describes the PFBs submitted by the validators
+type Submission struct {
+	SubmitTimes int `param:"submit-times" default:"1"`
+	MsgSize     int `param:"msg-size"`
+}
+
+func (s *Submission) Validate() error {
+	return errors.Join(
+		AtLeast("submit-times", s.SubmitTimes, 0),
+		AtLeast("msg-size", s.MsgSize, 1),
+	)
+}
+
+// DANode is the configuration shared by the bridge, full and light nodes
+type DANode struct {
+	P2PNetwork           string `param:"p2p-network" default:"private"`
+	OtelCollectorAddress string `param:"otel-collector-address"`
+	PeersLimit           int    `param:"peers-limit" default:"3"`
+	Bootstrapper         bool   `param:"bootstrapper" default:"false"`
+	// BlockHeight is the height the nodes have to reach
+	BlockHeight int `param:"block-height"`
+}
+
+func (n *DANode) Validate() error {
+	var err error
+	if n.P2PNetwork == "" {
+		err = fmt.Errorf("p2p-network must not be empty")
+	}
+	return errors.Join(
+		err,
+		AtLeast("peers-limit", n.PeersLimit, 0),
+		AtLeast("block-height", n.BlockHeight, 1),
+	)
+}
+
+// Getter selects how the full nodes retrieve the shares of the blocks
+type Getter struct {
+	// Name is either "shrex" or "ipld"
+	Name string `param:"getter" default:"ipld"`
+}
+
+func (g *Getter) Validate() error {
+	switch g.Name {
+	case "shrex", "ipld":
+		return nil
+	default:
+		return fmt.Errorf("unknown getter %q, supported are shrex and ipld", g.Name)
+	}
+}
+
+// UseShareExchange reports whether the shares are retrieved through shrex
+func (g *Getter) UseShareExchange() bool {
+	return g.Name == "shrex"
+}
+
+// Assignment selects the validator every bridge connects to and the bridges
+// every full and light node connects to, see assignkit for the strategies
+type Assignment struct {
+	BridgeAssignment    string `param:"bridge-assignment" default:"round-robin"`
+	ValidatorAssignment string `param:"validator-assignment" default:"round-robin"`
+	AssignmentSeed      int64  `param:"assignment-seed" default:"0"`
+	// FanIn is the amount of servers the fan-in strategy concentrates the clients on
+	FanIn int `param:"fan-in" default:"1"`
+	// Zone of the instance, the locality strategy assigns the servers of the same zone
+	Zone string `param:"zone" default:""`
+}
+
+func (a *Assignment) Validate() error {
+	return errors.Join(
+		wrap("bridge-assignment", a.BridgeConfig().Validate()),
+		wrap("validator-assignment", a.ValidatorConfig().Validate()),
+	)
+}
+
+// BridgeConfig assigns the bridges to the full and light nodes
+func (a *Assignment) BridgeConfig() assignkit.Config {
+	return assignkit.Config{
+		Strategy: assignkit.Strategy(a.BridgeAssignment),
+		Seed:     a.AssignmentSeed,
+		FanIn:    a.FanIn,
+	}
+}
+
+// ValidatorConfig assigns the validators to the bridges
+func (a *Assignment) ValidatorConfig() assignkit.Config {
+	return assignkit.Config{
+		Strategy: assignkit.Strategy(a.ValidatorAssignment),
+		Seed:     a.AssignmentSeed,
+		FanIn:    a.FanIn,
+	}
+}
+
+// PeerGraph selects the graph of the persistent peers of the validators, see graphkit.
+// The persistent-peers param is its degree
+type PeerGraph struct {
+	Kind string `param:"peer-graph" default:"random-regular"`
+	// Rewire is the probability the small-world graph rewires an edge
+	Rewire float64 `param:"peer-rewire" default:"0.1"`
+	// Hubs is the amount of centers of the star
+	Hubs int `param:"peer-hubs" default:"1"`
+}
+
+func (p *PeerGraph) Validate() error {
+	return wrap("peer-graph", p.Config(0, 0).Validate())
+}
+
+// Config is the config of the graph with the given degree and seed
+func (p *PeerGraph) Config(degree int, seed int64) graphkit.Config {
+	return graphkit.Config{
+		Kind:   graphkit.Kind(p.Kind),
+		Degree: degree,
+		Rewire: p.Rewire,
+		Hubs:   p.Hubs,
+		Seed:   seed,
+	}
+}
+
+// Genesis customizes the genesis of the validators, see appkit.Genesis.
+// The zero values keep the defaults of celestia-app
+type Genesis struct {
+	MaxBlockBytes int64         `param:"max-block-bytes" default:"0"`
+	TimeIota      time.Duration `param:"time-iota" default:"0s"`
+	MaxSquareSize uint64        `param:"max-square-size" default:"0"`
+	UnbondingTime time.Duration `param:"unbonding-time" default:"0s"`
+	// MinGasPrice is in utia, accepted by every validator
+	MinGasPrice      float64 `param:"min-gas-price" default:"0"`
+	ValidatorBalance int64   `param:"validator-balance" default:"10000000000000000"`
+	// StakeDistribution, Stake and StakeFactor decide the self-delegation of every validator
+	StakeDistribution string  `param:"stake-distribution" default:"equal"`
+	Stake             int64   `param:"stake" default:"5000000000"`
+	StakeFactor       float64 `param:"stake-factor" default:"2"`
+	// PrefundDANodes funds the account of every instance in the genesis, see appkit.PrefundedKey
+	PrefundDANodes bool  `param:"prefund-da-nodes" default:"false"`
+	DANodeBalance  int64 `param:"da-node-balance" default:"1000000000000"`
+}
+
+func (g *Genesis) Validate() error {
+	var errs []error
+	if g.MinGasPrice < 0 {
+		errs = append(errs, fmt.Errorf("min-gas-price must be >= 0, got %v", g.MinGasPrice))
+	}
+	if g.ValidatorBalance <= 0 {
+		errs = append(errs, fmt.Errorf("validator-balance must be > 0, got %d", g.ValidatorBalance))
+	}
+	if g.PrefundDANodes && g.DANodeBalance <= 0 {
+		errs = append(errs, fmt.Errorf("da-node-balance must be > 0, got %d", g.DANodeBalance))
+	}
+	return errors.Join(append(errs,
+		wrap("genesis", g.Params().Validate()),
+		wrap("stake-distribution", g.Stakes().Validate()),
+	)...)
+}
+
+// Params are the consensus and module params of the genesis, without any account
+func (g *Genesis) Params() *appkit.Genesis {
+	return &appkit.Genesis{
+		MaxBlockBytes:    g.MaxBlockBytes,
+		TimeIota:         g.TimeIota,
+		GovMaxSquareSize: g.MaxSquareSize,
+		UnbondingTime:    g.UnbondingTime,
+	}
+}
+
+// Stakes are the self-delegations of the validators
+func (g *Genesis) Stakes() appkit.Stakes {
+	return appkit.Stakes{
+		Distribution: appkit.StakeDistribution(g.StakeDistribution),
+		Base:         g.Stake,
+		Factor:       g.StakeFactor,
+	}
+}
+
+// Chaos crashes and restarts some of the nodes during the run, see faultkit
+type Chaos struct {
+	// CrashSchedule are the faults of every crashing node, e.g. "h20/30s,t5m/1m"
+	CrashSchedule string `param:"crash-schedule" default:""`
+	// CrashRoles are the comma separated roles crashing, e.g. "bridge,full"
+	CrashRoles string `param:"crash-roles" default:""`
+	// CrashInstances is the amount of instances of every crashing role, the first ones of their group
+	CrashInstances   int           `param:"crash-instances" default:"1"`
+	RecoveryDeadline time.Duration `param:"recovery-deadline" default:"5m"`
+}
+
+// crashableRoles are the roles with a fault target
+var crashableRoles = map[string]bool{"validator": true, "bridge": true, "full": true, "light": true}
+
+func (c *Chaos) Validate() error {
+	var errs []error
+	for _, role := range c.Roles() {
+		if !crashableRoles[role] {
+			errs = append(errs, fmt.Errorf("role %q can't crash, supported are validator, bridge, full and light", role))
+		}
+	}
+	_, err := c.Schedule()
+	return errors.Join(append(errs,
+		wrap("crash-schedule", err),
+		AtLeast("crash-instances", c.CrashInstances, 0),
+	)...)
+}
+
+// Roles returns the crashing roles
+func (c *Chaos) Roles() []string {
+	var roles []string
+	for _, role := range strings.Split(c.CrashRoles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// Crashes reports whether the instance of the role with the group sequence number crashes
+func (c *Chaos) Crashes(role string, groupSeq int64) bool {
+	if c.CrashSchedule == "" || groupSeq > int64(c.CrashInstances) {
+		return false
+	}
+	for _, r := range c.Roles() {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Schedule is the schedule of the faults of every crashing node
+func (c *Chaos) Schedule() (faultkit.Schedule, error) {
+	return faultkit.ParseSchedule(c.CrashSchedule, c.RecoveryDeadline)
+}
+
+// Partition cuts groups of instances off from each other at a height and heals it
+// after a while, see netkit.ParsePartition for the groups
+type Partition struct {
+	// Partition is the JSON array of groups, e.g. [["light"], ["bridge"]]
+	Partition         string        `param:"partition" default:""`
+	PartitionHeight   int           `param:"partition-height" default:"5"`
+	PartitionDuration time.Duration `param:"partition-duration" default:"1m"`
+	// HealDeadline bounds the time all the instances have to converge after the heal
+	HealDeadline time.Duration `param:"heal-deadline" default:"5m"`
+}
+
+func (p *Partition) Validate() error {
+	groups, err := p.Groups()
+	errs := []error{wrap("partition", err)}
+	for _, group := range groups {
+		for _, sel := range group {
+			// the instances taking part in a partition are the ones with a fault target
+			if !crashableRoles[sel.Role] {
+				errs = append(errs, fmt.Errorf("role %q can't be partitioned, supported are validator, bridge, full and light", sel.Role))
+			}
+		}
+	}
+	if p.PartitionDuration <= 0 || p.HealDeadline <= 0 {
+		errs = append(errs, fmt.Errorf("partition-duration and heal-deadline must be > 0"))
+	}
+	return errors.Join(append(errs,
+		AtLeast("partition-height", p.PartitionHeight, 1),
+	)...)
+}
+
+// Groups are the groups of the partition, nil without any partition
+func (p *Partition) Groups() (netkit.Partition, error) {
+	return netkit.ParsePartition(p.Partition)
+}
+
+// Byzantine turns the first byzantine-bridges bridges byzantine, see testkit/byzkit
+type Byzantine struct {
+	ByzantineBridges int    `param:"byzantine-bridges" default:"1"`
+	Attack           string `param:"attack" default:"withhold"`
+	// AttackHeights are the comma separated heights and ranges of heights, e.g. "10,12-14"
+	AttackHeights string `param:"attack-heights" default:"10"`
+	// WithholdFraction is the fraction of the extended squares withheld by the withhold attack
+	WithholdFraction float64 `param:"withhold-fraction" default:"1"`
+	// DetectionDeadline bounds the time the full and light nodes have to detect every attacked height
+	DetectionDeadline time.Duration `param:"detection-deadline" default:"10m"`
+}
+
+func (b *Byzantine) Validate() error {
+	_, err := b.Config()
+	var deadline error
+	if b.DetectionDeadline <= 0 {
+		deadline = fmt.Errorf("detection-deadline must be > 0, got %s", b.DetectionDeadline)
+	}
+	return errors.Join(
+		err,
+		deadline,
+		AtLeast("byzantine-bridges", b.ByzantineBridges, 1),
+	)
+}
+
+// IsByzantine reports whether the bridge with the group sequence number is byzantine
+func (b *Byzantine) IsByzantine(groupSeq int64) bool {
+	return groupSeq <= int64(b.ByzantineBridges)
+}
+
+// Config is the attack of every byzantine bridge, the bridges set the seed of the run
+func (b *Byzantine) Config() (byzkit.Config, error) {
+	attack, err := byzkit.ParseAttack(b.Attack)
+	if err != nil {
+		return byzkit.Config{}, wrap("attack", err)
+	}
+	heights, err := byzkit.ParseHeights(b.AttackHeights)
+	if err != nil {
+		return byzkit.Config{}, wrap("attack-heights", err)
+	}
+
+	cfg := byzkit.Config{Attack: attack, Heights: heights, Fraction: b.WithholdFraction}
+	return cfg, cfg.Validate()
+}
+
+// Sampling is how often the progress of the DASer is polled, see testkit/daskit
+type Sampling struct {
+	SamplingStatsInterval time.Duration `param:"sampling-stats-interval" default:"5s"`
+}
+
+func (s *Sampling) Validate() error {
+	if s.SamplingStatsInterval <= 0 {
+		return fmt.Errorf("sampling-stats-interval must be > 0, got %s", s.SamplingStatsInterval)
+	}
+	return nil
+}
+
+// Bootstrap selects the peers the full nodes trust on startup
+type Bootstrap struct {
+	// Multibootstrap makes the full nodes trust several bridges instead of their assigned ones
+	Multibootstrap bool `param:"multibootstrap" default:"false"`
+	// BootstrapBridges is the amount of bridges trusted with multibootstrap, all of them if 0
+	BootstrapBridges int `param:"bootstrap-bridges" default:"0"`
+	// BootstrapFulls is the amount of full nodes of the same group trusted with multibootstrap
+	BootstrapFulls int `param:"bootstrap-fulls" default:"0"`
+	// CheckBootstrap fails the full nodes which are not connected to all their trusted peers
+	CheckBootstrap bool `param:"check-bootstrap" default:"false"`
+}
+
+func (b *Bootstrap) Validate() error {
+	var err error
+	if !b.Multibootstrap && (b.BootstrapBridges > 0 || b.BootstrapFulls > 0) {
+		err = fmt.Errorf("bootstrap-bridges and bootstrap-fulls require multibootstrap")
+	}
+	return errors.Join(
+		err,
+		AtLeast("bootstrap-bridges", b.BootstrapBridges, 0),
+		AtLeast("bootstrap-fulls", b.BootstrapFulls, 0),
+	)
+}
+
+func wrap(name string, err error) error {
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// AtLeast checks that the param is at least min
+func AtLeast(name string, value, min int) error {
+	if value < min {
+		return fmt.Errorf("%s must be >= %d, got %d", name, min, value)
+	}
+	return nil
+}
+
+// AtMost checks that the param is at most max
+func AtMost(name string, value, max int) error {
+	if value > max {
+		return fmt.Errorf("%s must be <= %d, got %d", name, max, value)
+	}
+	return nil
+}
@@ -0,0 +1,343 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/testground/sdk-go/network"
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+	"github.com/testground/sdk-go/sync"
+
+	"github.com/celestiaorg/test-infra/testkit"
+	"github.com/celestiaorg/test-infra/testkit/appkit"
+	"github.com/celestiaorg/test-infra/testkit/faultkit"
+	"github.com/celestiaorg/test-infra/testkit/netkit"
+	"github.com/celestiaorg/test-infra/testkit/paramkit"
+	"github.com/celestiaorg/test-infra/testkit/waitkit"
+)
+
+// partitionSampleInterval is the interval the heights are sampled at during a partition
+const partitionSampleInterval = time.Second
+
+// partitionResult is written to the partition.json output of every instance
+type partitionResult struct {
+	Group int `json:"group"`
+	// HealHeight is the height of the instance when the partition healed
+	HealHeight uint64 `json:"heal_height"`
+	// MaxDivergence is the highest difference between the highest and the lowest instance
+	// over the samples of the partition and the heal
+	MaxDivergence uint64 `json:"max_divergence"`
+	// DivergenceAtHeal is the difference between the highest and the lowest instance at the heal
+	DivergenceAtHeal uint64        `json:"divergence_at_heal"`
+	TimeToHeal       time.Duration `json:"time_to_heal"`
+}
+
+// PartitionValidator runs the partition of the params on the validator started in this instance
+func PartitionValidator(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	initCtx *run.InitContext,
+	app *appkit.AppKit,
+) error {
+	target := &faultkit.Validator{App: app, LogLevel: "info", IP: net.ParseIP("127.0.0.1")}
+	return RunPartition(ctx, runenv, initCtx, "validator", target)
+}
+
+// RunPartition cuts the instance off from the other groups of the partition param once the
+// first validator reaches the partition-height, heals it after the partition-duration and
+// waits until the target is synced past the highest instance at the heal. All the validators,
+// bridges, full and light nodes of the test-case have to call it, even when they are in no group
+func RunPartition(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	initCtx *run.InitContext,
+	role string,
+	target faultkit.Target,
+) error {
+	var (
+		p    Partition
+		topo Topology
+	)
+	err := paramkit.Load(runenv, &p)
+	if err != nil {
+		return err
+	}
+	partition, err := p.Groups()
+	if err != nil || partition == nil {
+		return err
+	}
+	err = paramkit.Load(runenv, &topo)
+	if err != nil {
+		return err
+	}
+	participants := topo.Validator + topo.Bridge + topo.Full + topo.Light
+
+	members, err := getPartitionMembers(ctx, initCtx, role, participants)
+	if err != nil {
+		return err
+	}
+	groups := partition.Groups(members)
+	group := groups[initCtx.GlobalSeq]
+	runenv.RecordMessage("%s is in group %d of the partition %s", role, group, p.Partition)
+
+	// the first validator decides when the partition starts for everyone
+	if role == "validator" && initCtx.GroupSeq == 1 {
+		err = waitkit.Until(ctx, waitkit.DefaultConfig, func(ctx context.Context) (bool, error) {
+			h, err := target.Height(ctx)
+			return h >= uint64(p.PartitionHeight), err
+		})
+		if err != nil {
+			return err
+		}
+		_, err = initCtx.SyncClient.SignalEntry(ctx, testkit.PartitionStartState)
+		if err != nil {
+			return err
+		}
+	}
+	err = waitkit.ForBarrier(ctx, initCtx.SyncClient, testkit.PartitionStartState, 1)
+	if err != nil {
+		return err
+	}
+
+	rules := netkit.PartitionRules(runenv.TestSubnet, initCtx.GlobalSeq, groups)
+	err = reconfigureNetwork(ctx, runenv, initCtx, "partition", rules)
+	if err != nil {
+		return err
+	}
+	runenv.RecordMessage("Partitioned from %d instances for %s", len(rules), p.PartitionDuration)
+
+	heights, err := sampleHeights(ctx, target, p.PartitionDuration)
+	if err != nil {
+		return err
+	}
+
+	height, err := target.Height(ctx)
+	if err != nil {
+		return err
+	}
+	err = reconfigureNetwork(ctx, runenv, initCtx, "heal", nil)
+	if err != nil {
+		return err
+	}
+	healedAt := time.Now()
+
+	reports, err := exchangePartitionReports(ctx, initCtx, &testkit.PartitionReport{
+		GlobalSeq: initCtx.GlobalSeq,
+		Role:      role,
+		Group:     group,
+		Height:    height,
+		Heights:   heights,
+	}, participants)
+	if err != nil {
+		return err
+	}
+	maxHeight, atHeal, maxDivergence := divergence(reports)
+
+	cfg := waitkit.DefaultConfig
+	cfg.Timeout = p.HealDeadline
+	err = waitkit.Until(ctx, cfg, func(ctx context.Context) (bool, error) {
+		return target.Synced(ctx, maxHeight)
+	})
+	if err != nil {
+		return fmt.Errorf("%s converging past height %d after the heal: %w", role, maxHeight, err)
+	}
+
+	res := &partitionResult{
+		Group:            group,
+		HealHeight:       height,
+		MaxDivergence:    maxDivergence,
+		DivergenceAtHeal: atHeal,
+		TimeToHeal:       time.Since(healedAt),
+	}
+	runenv.R().RecordPoint("partition.time_to_heal_ms", float64(res.TimeToHeal.Milliseconds()))
+	runenv.R().RecordPoint("partition.max_divergence", float64(res.MaxDivergence))
+	runenv.R().RecordPoint("partition.divergence_at_heal", float64(res.DivergenceAtHeal))
+	runenv.R().RecordPoint("partition.heal_height", float64(res.HealHeight))
+	runenv.RecordMessage(
+		"%s converged %s after the heal, heights diverged by up to %d blocks and by %d at the heal",
+		role, res.TimeToHeal, res.MaxDivergence, res.DivergenceAtHeal,
+	)
+
+	f, err := runenv.CreateRawAsset("partition.json")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return json.NewEncoder(f).Encode(res)
+}
+
+// reconfigureNetwork applies the network config of the instance with the extra link rules.
+// Every instance waits for its own sidecar only, under a state of its own for the step
+func reconfigureNetwork(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	initCtx *run.InitContext,
+	step string,
+	rules []network.LinkRule,
+) error {
+	cfg, err := netkit.NewConfig(runenv, initCtx)
+	if err != nil {
+		return err
+	}
+	cfg.Rules = append(cfg.Rules, rules...)
+	cfg.CallbackState = sync.State(fmt.Sprintf("%s-%d", step, initCtx.GlobalSeq))
+	cfg.CallbackTarget = 1
+
+	return initCtx.NetClient.ConfigureNetwork(ctx, cfg)
+}
+
+// getPartitionMembers publishes the role of the instance and waits for all the participants
+func getPartitionMembers(
+	ctx context.Context,
+	initCtx *run.InitContext,
+	role string,
+	participants int,
+) ([]netkit.Member, error) {
+	syncclient := initCtx.SyncClient
+	_, err := syncclient.Publish(ctx, testkit.PartitionMemberTopic, &netkit.Member{
+		GlobalSeq: initCtx.GlobalSeq,
+		Role:      role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	memberCh := make(chan *netkit.Member)
+	sub, err := syncclient.Subscribe(ctx, testkit.PartitionMemberTopic, memberCh)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		members []netkit.Member
+		seen    = make(map[int64]bool)
+	)
+	for len(members) < participants {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("received %d out of %d partition members: %w", len(members), participants, ctx.Err())
+		case err = <-sub.Done():
+			if err != nil {
+				return nil, err
+			}
+		case m := <-memberCh:
+			if !seen[m.GlobalSeq] {
+				seen[m.GlobalSeq] = true
+				members = append(members, *m)
+			}
+		}
+	}
+	return members, nil
+}
+
+// sampleHeights returns the heights of the target sampled every partitionSampleInterval
+// from now until the end of the duration
+func sampleHeights(ctx context.Context, target faultkit.Target, duration time.Duration) ([]uint64, error) {
+	ticker := time.NewTicker(partitionSampleInterval)
+	defer ticker.Stop()
+	end := time.NewTimer(duration)
+	defer end.Stop()
+
+	var heights []uint64
+	for {
+		h, err := target.Height(ctx)
+		if err != nil {
+			return nil, err
+		}
+		heights = append(heights, h)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-end.C:
+			return heights, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// exchangePartitionReports publishes the report of the instance and returns the reports
+// of all the participants, once each
+func exchangePartitionReports(
+	ctx context.Context,
+	initCtx *run.InitContext,
+	report *testkit.PartitionReport,
+	participants int,
+) ([]*testkit.PartitionReport, error) {
+	syncclient := initCtx.SyncClient
+	_, err := syncclient.Publish(ctx, testkit.PartitionReportTopic, report)
+	if err != nil {
+		return nil, err
+	}
+
+	reportCh := make(chan *testkit.PartitionReport)
+	sub, err := syncclient.Subscribe(ctx, testkit.PartitionReportTopic, reportCh)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		reports []*testkit.PartitionReport
+		seen    = make(map[int64]bool)
+	)
+	for len(reports) < participants {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("received %d out of %d partition reports: %w", len(reports), participants, ctx.Err())
+		case err = <-sub.Done():
+			if err != nil {
+				return nil, err
+			}
+		case r := <-reportCh:
+			if !seen[r.GlobalSeq] {
+				seen[r.GlobalSeq] = true
+				reports = append(reports, r)
+			}
+		}
+	}
+	return reports, nil
+}
+
+// divergence returns the highest height at the heal, the difference between the highest and
+// the lowest heights at the heal and the highest difference over the samples and the heal.
+// A sample is compared between the instances which took it, as the last one may be missed
+func divergence(reports []*testkit.PartitionReport) (maxHeight, atHeal, maxDivergence uint64) {
+	spread := func(heights []uint64) uint64 {
+		if len(heights) == 0 {
+			return 0
+		}
+		lo, hi := heights[0], heights[0]
+		for _, h := range heights[1:] {
+			lo, hi = min(lo, h), max(hi, h)
+		}
+		return hi - lo
+	}
+
+	var (
+		healHeights []uint64
+		samples     int
+	)
+	for _, r := range reports {
+		healHeights = append(healHeights, r.Height)
+		maxHeight = max(maxHeight, r.Height)
+		samples = max(samples, len(r.Heights))
+	}
+	atHeal = spread(healHeights)
+	maxDivergence = atHeal
+
+	for k := 0; k < samples; k++ {
+		var heights []uint64
+		for _, r := range reports {
+			if k < len(r.Heights) {
+				heights = append(heights, r.Heights[k])
+			}
+		}
+		maxDivergence = max(maxDivergence, spread(heights))
+	}
+	return maxHeight, atHeal, maxDivergence
+}
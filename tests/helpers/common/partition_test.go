@@ -0,0 +1,55 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/test-infra/testkit"
+)
+
+func TestDivergence(t *testing.T) {
+	tests := []struct {
+		name          string
+		reports       []*testkit.PartitionReport
+		maxHeight     uint64
+		atHeal        uint64
+		maxDivergence uint64
+	}{
+		{
+			name:      "no sample",
+			reports:   []*testkit.PartitionReport{{Height: 10}, {Height: 7}},
+			maxHeight: 10, atHeal: 3, maxDivergence: 3,
+		},
+		{
+			name: "diverged during the partition",
+			reports: []*testkit.PartitionReport{
+				{Height: 12, Heights: []uint64{5, 8, 11}},
+				{Height: 12, Heights: []uint64{5, 5, 5}},
+			},
+			maxHeight: 12, atHeal: 0, maxDivergence: 6,
+		},
+		{
+			name: "last sample missed",
+			reports: []*testkit.PartitionReport{
+				{Height: 9, Heights: []uint64{5, 6, 9}},
+				{Height: 6, Heights: []uint64{5, 6}},
+			},
+			maxHeight: 9, atHeal: 3, maxDivergence: 3,
+		},
+		{
+			name: "single instance",
+			reports: []*testkit.PartitionReport{
+				{Height: 8, Heights: []uint64{5, 8}},
+			},
+			maxHeight: 8, atHeal: 0, maxDivergence: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxHeight, atHeal, maxDivergence := divergence(tt.reports)
+			if maxHeight != tt.maxHeight || atHeal != tt.atHeal || maxDivergence != tt.maxDivergence {
+				t.Errorf("expected %d, %d and %d, got %d, %d and %d",
+					tt.maxHeight, tt.atHeal, tt.maxDivergence, maxHeight, atHeal, maxDivergence)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+	"github.com/testground/sdk-go/sync"
+
+	"github.com/celestiaorg/test-infra/testkit"
+	"github.com/celestiaorg/test-infra/testkit/appkit"
+	"github.com/celestiaorg/test-infra/testkit/graphkit"
+	"github.com/celestiaorg/test-infra/testkit/paramkit"
+	"github.com/celestiaorg/test-infra/testkit/randkit"
+)
+
+// GetValidatorPeers waits for the given amount of validators to publish their node ID and
+// returns them sorted by node ID, which numbers them the same way on every instance
+func GetValidatorPeers(ctx context.Context, syncclient sync.Client, valAmount int) ([]appkit.ValidatorNode, error) {
+	valCh := make(chan *appkit.ValidatorNode)
+	sub, err := syncclient.Subscribe(ctx, testkit.ValidatorPeerTopic, valCh)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		vals []appkit.ValidatorNode
+		seen = make(map[string]bool)
+	)
+	for len(vals) < valAmount {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("received %d out of %d validator peers: %w", len(vals), valAmount, ctx.Err())
+		case err = <-sub.Done():
+			if err != nil {
+				return nil, err
+			}
+		case val := <-valCh:
+			if !seen[val.PubKey] {
+				seen[val.PubKey] = true
+				vals = append(vals, *val)
+			}
+		}
+	}
+
+	sort.Slice(vals, func(i, j int) bool { return vals[i].PubKey < vals[j].PubKey })
+	return vals, nil
+}
+
+// ValidatorGraph generates the graph of the persistent peers of the validators, numbered
+// as returned by GetValidatorPeers, according to the peer-graph params and the
+// persistent-peers param as degree
+func ValidatorGraph(runenv *runtime.RunEnv, valAmount int) (graphkit.Config, *graphkit.Graph, error) {
+	var p PeerGraph
+	err := paramkit.Load(runenv, &p)
+	if err != nil {
+		return graphkit.Config{}, nil, err
+	}
+
+	cfg := p.Config(runenv.IntParam("persistent-peers"), randkit.Seed(runenv))
+	g, err := cfg.Generate(valAmount)
+	if err != nil {
+		return cfg, nil, err
+	}
+	return cfg, g, nil
+}
+
+// PublishPeerGraph publishes the graph to testkit.PeerGraphTopic and writes it to the
+// peer-graph.json output of the instance, for the analysis of the run.
+// A single instance of the graph is expected to publish it
+func PublishPeerGraph(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	initCtx *run.InitContext,
+	cfg graphkit.Config,
+	nodes []string,
+	g *graphkit.Graph,
+) error {
+	pg := &testkit.PeerGraph{
+		Kind:  string(cfg.Kind),
+		Seed:  cfg.Seed,
+		Nodes: nodes,
+		Edges: g.Edges(),
+	}
+
+	_, err := initCtx.SyncClient.Publish(ctx, testkit.PeerGraphTopic, pg)
+	if err != nil {
+		return err
+	}
+
+	f, err := runenv.CreateRawAsset("peer-graph.json")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return json.NewEncoder(f).Encode(pg)
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
-	util "github.com/ipfs/go-ipfs-util"
 	crypto2 "github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/testground/sdk-go/run"
 	"github.com/testground/sdk-go/runtime"
@@ -30,7 +29,7 @@ func BuildOrchestrator(ctx context.Context, runenv *runtime.RunEnv, initCtx *run
 		return nil, err
 	}
 
-	p2ppk, _, err := crypto2.GenerateEd25519Key(util.NewTimeSeededRand())
+	p2ppk, _, err := crypto2.GenerateEd25519Key(InstanceRand(runenv, initCtx, "qgb-p2p-key"))
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +75,7 @@ func BuildRelayer(ctx context.Context, runenv *runtime.RunEnv, initCtx *run.Init
 		return nil, err
 	}
 
-	p2ppk, _, err := crypto2.GenerateEd25519Key(util.NewTimeSeededRand())
+	p2ppk, _, err := crypto2.GenerateEd25519Key(InstanceRand(runenv, initCtx, "qgb-p2p-key"))
 	if err != nil {
 		return nil, err
 	}
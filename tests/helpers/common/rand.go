@@ -0,0 +1,19 @@
+package common
+
+import (
+	"math/rand"
+
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+
+	"github.com/celestiaorg/test-infra/testkit/randkit"
+)
+
+// InstanceRand returns the RNG of the instance for the purpose, derived from the
+// random-seed of the run and the global sequence number of the instance.
+// The seed is recorded to reproduce the run
+func InstanceRand(runenv *runtime.RunEnv, initCtx *run.InitContext, purpose string) *rand.Rand {
+	seed := randkit.Seed(runenv)
+	runenv.RecordMessage("%s=%d for %s of instance %d", randkit.Param, seed, purpose, initCtx.GlobalSeq)
+	return randkit.New(seed, initCtx.GlobalSeq, purpose)
+}
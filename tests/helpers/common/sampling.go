@@ -0,0 +1,39 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/testground/sdk-go/runtime"
+
+	"github.com/celestiaorg/test-infra/testkit/daskit"
+	"github.com/celestiaorg/test-infra/testkit/paramkit"
+)
+
+// CollectSampling starts polling the sampling stats of the node at the interval of
+// the sampling params, see StopSampling
+func CollectSampling(ctx context.Context, runenv *runtime.RunEnv, stats daskit.StatsFn) (*daskit.Collector, error) {
+	var s Sampling
+	err := paramkit.Load(runenv, &s)
+	if err != nil {
+		return nil, err
+	}
+
+	c := daskit.NewCollector(runenv, stats, s.SamplingStatsInterval)
+	c.Start(ctx)
+	return c, nil
+}
+
+// StopSampling stops the collector before the node stops, records the summary
+// and writes it to the sampling.json output
+func StopSampling(ctx context.Context, runenv *runtime.RunEnv, c *daskit.Collector) error {
+	summary := c.Stop(ctx)
+
+	f, err := runenv.CreateRawAsset("sampling.json")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return json.NewEncoder(f).Encode(summary)
+}
@@ -0,0 +1,115 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+	"github.com/testground/sdk-go/sync"
+
+	"github.com/celestiaorg/test-infra/testkit/synckit"
+)
+
+// readyState is signalled by the instances of the tests before they publish,
+// like the instances of a run waiting for the others to be up
+const readyState = sync.State("ready")
+
+// syncCase is a sync service the helpers waiting for the other instances are run against
+type syncCase struct {
+	name   string
+	faults synckit.Faults
+	// cancel cancels the context before the helpers are run
+	cancel bool
+	// err is the error the helpers are expected to wrap, nil when they get all the payloads
+	err error
+}
+
+var syncCases = []syncCase{
+	{name: "no fault"},
+	{name: "duplicated publishes", faults: synckit.Faults{Seed: 1, DuplicatePublish: 0.5}},
+	{name: "every publish duplicated", faults: synckit.Faults{Seed: 2, DuplicatePublish: 1}},
+	{name: "delayed barriers", faults: synckit.Faults{Seed: 3, BarrierDelay: 200 * time.Millisecond}},
+	{
+		name:   "dropped publishes of another topic",
+		faults: synckit.Faults{Seed: 4, DropPublish: 1, Topics: []string{"another-topic"}},
+	},
+	{
+		name:   "dropped publishes",
+		faults: synckit.Faults{Seed: 5, DropPublish: 1},
+		err:    context.DeadlineExceeded,
+	},
+	{name: "cancelled context", cancel: true, err: context.Canceled},
+}
+
+// ctx returns the context of the helpers, short when they are expected to time out
+func (c syncCase) ctx(t *testing.T) context.Context {
+	timeout := 10 * time.Second
+	if errors.Is(c.err, context.DeadlineExceeded) {
+		timeout = 500 * time.Millisecond
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	t.Cleanup(cancel)
+	if c.cancel {
+		cancel()
+	}
+	return ctx
+}
+
+// check fails the test unless err is the expected one
+func (c syncCase) check(t *testing.T, err error) {
+	t.Helper()
+	switch {
+	case c.err == nil && err != nil:
+		t.Fatalf("unexpected error: %s", err)
+	case c.err != nil && !errors.Is(err, c.err):
+		t.Fatalf("expected %v, got %v", c.err, err)
+	}
+}
+
+// newInstance returns the RunEnv and the InitContext of an instance of the group with
+// its own client of the service, closed at the end of the test
+func newInstance(
+	t *testing.T,
+	svc *synckit.Service,
+	group string,
+	seq int64,
+	params map[string]string,
+) (*runtime.RunEnv, *run.InitContext) {
+	t.Helper()
+
+	runenv := runtime.NewRunEnv(runtime.RunParams{
+		TestPlan:               "celestia",
+		TestCase:               t.Name(),
+		TestRun:                "test",
+		TestOutputsPath:        t.TempDir(),
+		TestInstanceCount:      1,
+		TestInstanceParams:     params,
+		TestGroupID:            group,
+		TestGroupInstanceCount: 1,
+		TestStartTime:          time.Now(),
+	})
+	client := svc.NewClient()
+	runenv.AttachSyncClient(client)
+	t.Cleanup(func() {
+		client.Close()
+		runenv.Close()
+	})
+
+	return runenv, &run.InitContext{SyncClient: client, GlobalSeq: seq, GroupSeq: seq}
+}
+
+// publishWhenReady publishes the payload once the amount of instances signalled readyState.
+// It's meant to run in its own goroutine, the errors of a done context are ignored
+func publishWhenReady(ctx context.Context, t *testing.T, client sync.Client, amount int, topic *sync.Topic, payload interface{}) {
+	_, err := client.SignalAndWait(ctx, readyState, amount)
+	if err == nil {
+		_, err = client.Publish(ctx, topic, payload)
+	}
+	if err != nil && ctx.Err() == nil {
+		t.Errorf("publishing: %s", err)
+	}
+}
@@ -8,12 +8,11 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
-
-	tmrand "github.com/tendermint/tendermint/libs/rand"
 
 	"github.com/celestiaorg/test-infra/testkit"
 	"github.com/celestiaorg/test-infra/testkit/appkit"
+	"github.com/celestiaorg/test-infra/testkit/paramkit"
+	"github.com/celestiaorg/test-infra/testkit/waitkit"
 	"github.com/testground/sdk-go/run"
 	"github.com/testground/sdk-go/runtime"
 	"github.com/testground/sdk-go/sync"
@@ -23,13 +22,28 @@ func BuildValidator(ctx context.Context, runenv *runtime.RunEnv, initCtx *run.In
 	home := "/.celestia-app"
 	runenv.RecordMessage(home)
 
+	var gen Genesis
+	err := paramkit.Load(runenv, &gen)
+	if err != nil {
+		return nil, err
+	}
+
+	// validators are numbered by their group sequence for the stake distribution
+	stake, err := gen.Stakes().Of(int(initCtx.GroupSeq) - 1)
+	if err != nil {
+		return nil, err
+	}
+	if stake > gen.ValidatorBalance {
+		return nil, fmt.Errorf("stake of %dutia is above the validator-balance of %dutia", stake, gen.ValidatorBalance)
+	}
+
 	cmd, keyringName, accAddr, err := InitChainAndMaybeBroadcastGenesis(ctx, runenv, initCtx, home)
 	if err != nil {
 		return nil, err
 	}
 
-	runenv.RecordMessage("Validator is signing its own GenTx")
-	_, err = cmd.SignGenTx(keyringName, "5000000000utia", "test", home)
+	runenv.RecordMessage("Validator is signing its own GenTx of %dutia", stake)
+	_, err = cmd.SignGenTx(keyringName, fmt.Sprintf("%dutia", stake), "test", home)
 	if err != nil {
 		return nil, err
 	}
@@ -44,6 +58,13 @@ func BuildValidator(ctx context.Context, runenv *runtime.RunEnv, initCtx *run.In
 		return nil, err
 	}
 
+	if gen.MinGasPrice > 0 {
+		err = appkit.SetMinGasPrice(home, gen.MinGasPrice)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if runenv.IntParam("validator") > 1 {
 		err := DiscoverPeers(ctx, home, ip, initCtx, runenv)
 		if err != nil {
@@ -51,6 +72,12 @@ func BuildValidator(ctx context.Context, runenv *runtime.RunEnv, initCtx *run.In
 		}
 	}
 
+	// the gas params are meant for the blobs, the funding keeps the DefaultFundStrategy
+	cmd.PFBStrategy, err = appkit.TxStrategyFromParams(runenv, appkit.DefaultPFBStrategy)
+	if err != nil {
+		return nil, err
+	}
+
 	return cmd, nil
 }
 
@@ -62,6 +89,12 @@ func InitChainAndMaybeBroadcastGenesis(
 ) (*appkit.AppKit, string, string, error) {
 	syncclient := initCtx.SyncClient
 
+	var gen Genesis
+	err := paramkit.Load(runenv, &gen)
+	if err != nil {
+		return nil, "", "", err
+	}
+
 	const chainId string = "private"
 	cmd := appkit.New(home, chainId)
 
@@ -74,9 +107,11 @@ func InitChainAndMaybeBroadcastGenesis(
 	cmd.ValopAddress = valopAddr
 	cmd.AccountName = keyringName
 
-	// we need this dirty-hack to check the k8s cluster has
-	// the time to ramp up all the instances
-	time.Sleep(30 * time.Second)
+	// wait for the k8s cluster to ramp up all the instances
+	err = waitkit.ForBarrier(ctx, syncclient, run.StateInitializedGlobal, runenv.TestInstanceCount)
+	if err != nil {
+		return nil, "", "", err
+	}
 
 	seq, err := syncclient.Publish(ctx, testkit.AccountAddressTopic, accAddr)
 	if err != nil {
@@ -89,10 +124,21 @@ func InitChainAndMaybeBroadcastGenesis(
 		return nil, "", "", err
 	}
 
-	var accounts []string
-	for i := 0; i < runenv.IntParam("validator"); i++ {
-		addr := <-accAddrCh
-		accounts = append(accounts, addr)
+	// a sync service may deliver the same address twice, so they are collected until all are distinct
+	var (
+		accounts []string
+		seen     = make(map[string]bool)
+	)
+	for len(accounts) < runenv.IntParam("validator") {
+		select {
+		case <-ctx.Done():
+			return nil, "", "", fmt.Errorf("collecting account addresses: %w", ctx.Err())
+		case addr := <-accAddrCh:
+			if !seen[addr] {
+				seen[addr] = true
+				accounts = append(accounts, addr)
+			}
+		}
 	}
 
 	moniker := fmt.Sprintf("validator-%d", initCtx.GroupSeq)
@@ -100,7 +146,7 @@ func InitChainAndMaybeBroadcastGenesis(
 	// Here we assign the first instance to be the orchestrator role
 	//
 	// Orchestrator is only initing the chain and sending the genesis.json
-	// to others, so the genesis time is the same everywhere
+	// to others, so the genesis time and params are the same everywhere
 	if seq == 1 {
 		_, err = cmd.InitChain(moniker)
 		if err != nil {
@@ -108,6 +154,11 @@ func InitChainAndMaybeBroadcastGenesis(
 		}
 		runenv.RecordMessage("Chain initialised")
 
+		err = appkit.PatchGenesis(fmt.Sprintf("%s/config/genesis.json", home), gen.Params())
+		if err != nil {
+			return nil, "", "", err
+		}
+
 		gen, err := os.Open(fmt.Sprintf("%s/config/genesis.json", home))
 		if err != nil {
 			return nil, "", "", err
@@ -131,6 +182,8 @@ func InitChainAndMaybeBroadcastGenesis(
 			return nil, "", "", err
 		}
 		select {
+		case <-ctx.Done():
+			return nil, "", "", fmt.Errorf("waiting for the initial genesis: %w", ctx.Err())
 		case err = <-sub.Done():
 			if err != nil {
 				return nil, "", "", err
@@ -145,17 +198,36 @@ func InitChainAndMaybeBroadcastGenesis(
 		runenv.RecordMessage("Validator has received the initial genesis")
 	}
 
-	for _, v := range accounts {
-		_, err := cmd.AddGenAccount(v, "10000000000000000utia")
-		if err != nil {
-			return nil, "", "", err
-		}
+	genAccs, err := genesisAccounts(runenv, &gen, accounts)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	err = cmd.ApplyGenesis(&appkit.Genesis{Accounts: genAccs})
+	if err != nil {
+		return nil, "", "", err
 	}
+	runenv.RecordMessage("Added %d genesis accounts", len(genAccs))
 
 	return cmd, keyringName, accAddr, nil
 }
 
 func BroadcastAndCollectGenTx(ctx context.Context, home string, accAddr string, initCtx *run.InitContext, runenv *runtime.RunEnv, cmd *appkit.AppKit) error {
+	err := exchangeGenTxs(ctx, initCtx.SyncClient, home, accAddr, runenv.IntParam("validator"))
+	if err != nil {
+		return err
+	}
+
+	_, err = cmd.CollectGenTxs()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// exchangeGenTxs publishes the gentxs of the validator and writes the ones of the
+// other validators next to them, until the gentxs of all the validators are there
+func exchangeGenTxs(ctx context.Context, syncclient sync.Client, home string, accAddr string, valAmount int) error {
 	fs, err := os.ReadDir(fmt.Sprintf("%s/config/gentx", home))
 	if err != nil {
 		return err
@@ -168,78 +240,50 @@ func BroadcastAndCollectGenTx(ctx context.Context, home string, accAddr string,
 		}
 
 		bt, err := io.ReadAll(gentx)
+		gentx.Close()
 		if err != nil {
 			return err
 		}
 
-		_, err = initCtx.SyncClient.Publish(ctx, testkit.GenesisTxTopic, string(bt))
+		_, err = syncclient.Publish(ctx, testkit.GenesisTxTopic, string(bt))
 		if err != nil {
 			return err
 		}
 	}
 
 	genTxCh := make(chan string)
-	sub, err := initCtx.SyncClient.Subscribe(ctx, testkit.GenesisTxTopic, genTxCh)
+	sub, err := syncclient.Subscribe(ctx, testkit.GenesisTxTopic, genTxCh)
 	if err != nil {
 		return err
 	}
 
-	for i := 0; i < runenv.IntParam("validator"); i++ {
+	// duplicated gentxs are skipped, as collecting them would declare the same validator twice
+	seen := make(map[string]bool)
+	for len(seen) < valAmount {
 		select {
+		case <-ctx.Done():
+			return fmt.Errorf("received %d out of %d gentxs: %w", len(seen), valAmount, ctx.Err())
 		case err = <-sub.Done():
 			if err != nil {
 				return err
 			}
 		case genTx := <-genTxCh:
+			if seen[genTx] {
+				continue
+			}
+			seen[genTx] = true
+
 			if !strings.Contains(genTx, accAddr) {
-				err := os.WriteFile(fmt.Sprintf("%s/config/gentx/%d.json", home, i), []byte(genTx), 0777)
+				err := os.WriteFile(fmt.Sprintf("%s/config/gentx/%d.json", home, len(seen)-1), []byte(genTx), 0777)
 				if err != nil {
 					return err
 				}
 			}
 		}
 	}
-
-	_, err = cmd.CollectGenTxs()
-	if err != nil {
-		return err
-	}
 	return nil
 }
 
-func GetRandomisedPeers(randomizer int, peersRange int, peers []appkit.ValidatorNode) []appkit.ValidatorNode {
-	// if the test-case wants only a single validator, then we return nil
-	if peersRange == 0 || randomizer > len(peers) {
-		return nil
-	}
-
-	// if peersRange is 1, then only one peer is added to the address book
-	if peersRange == 1 {
-		return []appkit.ValidatorNode{peers[randomizer]}
-	}
-
-	// if peersRange is greater or equal to the number of peers, then all peers are added to the address book
-	if peersRange >= len(peers) {
-		fmt.Println("peersRange is greater or equal than the number of peers, which is not ok")
-		return peers
-	}
-
-	startIndex := randomizer
-	endIndex := randomizer + peersRange
-	// if the endIndex is greater than the number of peers, then we set the endIndex to the number of peers
-	// this is to avoid out of bounds error when slicing the peers array
-	if endIndex > len(peers) && ((endIndex - len(peers)) < peersRange) {
-		startIndex = endIndex - len(peers)
-		endIndex = len(peers)
-	} else if (endIndex - startIndex) > peersRange {
-		endIndex = startIndex + peersRange
-	} else if (endIndex - startIndex) < peersRange {
-		startIndex = endIndex - peersRange
-	}
-
-	return peers[startIndex:endIndex]
-}
-
 func UpdateAndPublishConfig(
 	ctx context.Context,
 	home string,
@@ -281,45 +325,55 @@ func UpdateAndPublishConfig(
 	return ip, nil
 }
 
+// DiscoverPeers waits for all the validators and adds the peers of the validator in the peer
+// graph to its address book. The first validator publishes the graph
 func DiscoverPeers(ctx context.Context, home string, ip net.IP, initCtx *run.InitContext, runenv *runtime.RunEnv) error {
-	syncclient := initCtx.SyncClient
-	valCh := make(chan *appkit.ValidatorNode)
-	sub, err := syncclient.Subscribe(ctx, testkit.ValidatorPeerTopic, valCh)
+	runenv.RecordMessage("Discovering peers")
+	vals, err := GetValidatorPeers(ctx, initCtx.SyncClient, runenv.IntParam("validator"))
 	if err != nil {
 		return err
 	}
 
-	runenv.RecordMessage("Discovering peers")
-	var peers []appkit.ValidatorNode
-	for i := 0; i < runenv.IntParam("validator"); i++ {
-		select {
-		case err = <-sub.Done():
-			if err != nil {
-				return err
-			}
-		case val := <-valCh:
-			if !val.IP.Equal(ip) {
-				peers = append(peers, *val)
-			}
+	self := -1
+	nodes := make([]string, len(vals))
+	for i, val := range vals {
+		nodes[i] = val.PubKey
+		if val.IP.Equal(ip) {
+			self = i
 		}
 	}
-	runenv.RecordMessage("Validator Received is equal to: %d", len(peers))
-	randomizer := tmrand.Intn(runenv.IntParam("validator"))
-	runenv.RecordMessage("Randomized number is equal to: %d", randomizer)
-	peersRange := runenv.IntParam("persistent-peers")
-	runenv.RecordMessage("Peers Range is equal to: %d", peersRange)
-	randPeers := GetRandomisedPeers(randomizer, peersRange, peers)
-	if randPeers == nil {
+	if self < 0 {
+		return fmt.Errorf("validator with ip %s is not among the %d discovered ones", ip, len(vals))
+	}
+
+	cfg, g, err := ValidatorGraph(runenv, len(vals))
+	if err != nil {
+		return err
+	}
+	runenv.RecordMessage("Validator %d out of %d in the %s peer graph with seed %d", self, len(vals), cfg.Kind, cfg.Seed)
+
+	if self == 0 {
+		err = PublishPeerGraph(ctx, runenv, initCtx, cfg, nodes, g)
+		if err != nil {
+			return err
+		}
+	}
+
+	peers := make([]appkit.ValidatorNode, 0, len(g.Peers[self]))
+	for _, i := range g.Peers[self] {
+		peers = append(peers, vals[i])
+	}
+	if len(peers) == 0 {
 		runenv.RecordMessage("No peers added to the address book")
 		return nil
 	}
 
-	err = appkit.AddPeersToAddressBook(home, randPeers)
+	err = appkit.AddPeersToAddressBook(home, peers)
 	if err != nil {
 		return err
 	}
 
-	runenv.RecordMessage("Added %d to the address book", len(randPeers))
+	runenv.RecordMessage("Added %d to the address book", len(peers))
 	return nil
 }
 
@@ -369,24 +423,3 @@ func changeConfig(path, mempool string) error {
 	}
 	return nil
 }
-
-func GetValidatorInfo(ctx context.Context, syncclient sync.Client, valAmount, id int) (*testkit.AppNodeInfo, error) {
-	appInfoCh := make(chan *testkit.AppNodeInfo, valAmount)
-	sub, err := syncclient.Subscribe(ctx, testkit.AppNodeTopic, appInfoCh)
-	if err != nil {
-		return nil, err
-	}
-
-	for {
-		select {
-		case err = <-sub.Done():
-			if err != nil {
-				return nil, fmt.Errorf("no app has been sent for this node to connect to remotely")
-			}
-		case appInfo := <-appInfoCh:
-			if (appInfo.ID % valAmount) == (id % valAmount) {
-				return appInfo, nil
-			}
-		}
-	}
-}
@@ -0,0 +1,151 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/celestiaorg/test-infra/testkit"
+	"github.com/celestiaorg/test-infra/testkit/appkit"
+	"github.com/celestiaorg/test-infra/testkit/synckit"
+)
+
+func TestDiscoverPeers(t *testing.T) {
+	const (
+		validators = 4
+		degree     = 2
+	)
+	for _, c := range syncCases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			svc := synckit.NewServiceWithFaults(c.faults)
+			ctx := c.ctx(t)
+
+			homes := make([]string, validators)
+			errs := make(chan error, validators)
+			for i := range homes {
+				runenv, initCtx := newInstance(t, svc, "validators", int64(i+1), map[string]string{
+					"validator":        strconv.Itoa(validators),
+					"persistent-peers": strconv.Itoa(degree),
+					"random-seed":      "7",
+				})
+				homes[i] = t.TempDir()
+				err := os.Mkdir(filepath.Join(homes[i], "config"), 0o755)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				ip := net.IPv4(10, 0, 2, byte(i+1))
+				go publishWhenReady(ctx, t, initCtx.SyncClient, validators, testkit.ValidatorPeerTopic, &appkit.ValidatorNode{
+					PubKey: fmt.Sprintf("%040x", i+1),
+					IP:     ip,
+				})
+				go func(home string) {
+					errs <- DiscoverPeers(ctx, home, ip, initCtx, runenv)
+				}(homes[i])
+			}
+			for range homes {
+				c.check(t, <-errs)
+			}
+			if c.err != nil {
+				return
+			}
+
+			for i, home := range homes {
+				bt, err := os.ReadFile(filepath.Join(home, "config", "addrbook.json"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				var book struct {
+					Addrs []json.RawMessage `json:"addrs"`
+				}
+				err = json.Unmarshal(bt, &book)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(book.Addrs) != degree {
+					t.Errorf("validator %d: expected %d peers in the address book, got %d", i+1, degree, len(book.Addrs))
+				}
+			}
+			if svc.Delivered(testkit.PeerGraphTopic) == 0 {
+				t.Error("the peer graph is not published")
+			}
+		})
+	}
+}
+
+// TestExchangeGenTxs covers the exchange of the gentxs of BroadcastAndCollectGenTx. It deliberately
+// stops short of the celestia-appd calls signing and collecting them, which need the binary
+func TestExchangeGenTxs(t *testing.T) {
+	const validators = 3
+	for _, c := range syncCases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			svc := synckit.NewServiceWithFaults(c.faults)
+			ctx := c.ctx(t)
+
+			homes := make([]string, validators)
+			errs := make(chan error, validators)
+			for i := range homes {
+				_, initCtx := newInstance(t, svc, "validators", int64(i+1), nil)
+				homes[i] = t.TempDir()
+				accAddr := fmt.Sprintf("celestia1validator%d", i+1)
+				err := os.MkdirAll(filepath.Join(homes[i], "config", "gentx"), 0o755)
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = os.WriteFile(filepath.Join(homes[i], "config", "gentx", "gentx-"+accAddr+".json"), []byte(genTx(accAddr)), 0o644)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				go func(home string) {
+					_, err := initCtx.SyncClient.SignalAndWait(ctx, readyState, validators)
+					if err == nil {
+						err = exchangeGenTxs(ctx, initCtx.SyncClient, home, accAddr, validators)
+					}
+					errs <- err
+				}(homes[i])
+			}
+			for range homes {
+				c.check(t, <-errs)
+			}
+			if c.err != nil {
+				return
+			}
+
+			// every validator has the gentx of every validator once, to collect them
+			for i, home := range homes {
+				fs, err := os.ReadDir(filepath.Join(home, "config", "gentx"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				got := make(map[string]int, len(fs))
+				for _, f := range fs {
+					bt, err := os.ReadFile(filepath.Join(home, "config", "gentx", f.Name()))
+					if err != nil {
+						t.Fatal(err)
+					}
+					got[string(bt)]++
+				}
+				for j := 1; j <= validators; j++ {
+					if tx := genTx(fmt.Sprintf("celestia1validator%d", j)); got[tx] != 1 {
+						t.Errorf("validator %d: expected the gentx of validator %d once, got it %d times", i+1, j, got[tx])
+					}
+				}
+				if len(fs) != validators {
+					t.Errorf("validator %d: expected %d gentxs, got %d", i+1, validators, len(fs))
+				}
+			}
+		})
+	}
+}
+
+// genTx is a stand-in for the gentx of the account, the helpers only look for the address in it
+func genTx(accAddr string) string {
+	return fmt.Sprintf(`{"body":{"messages":[{"delegator_address":%q}]}}`, accAddr)
+}
@@ -0,0 +1,249 @@
+package daswithholding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+
+	"github.com/celestiaorg/test-infra/testkit"
+	"github.com/celestiaorg/test-infra/testkit/byzkit"
+	"github.com/celestiaorg/test-infra/testkit/nodekit"
+	"github.com/celestiaorg/test-infra/testkit/waitkit"
+	"github.com/celestiaorg/test-infra/tests/helpers/common"
+)
+
+// detection is whether a light node detected a withheld square, written to the
+// withholding.json output
+type detection struct {
+	Height   uint64 `json:"height"`
+	Width    uint   `json:"width"`
+	Withheld int    `json:"withheld"`
+	// Probability is the theoretical probability of the detection
+	Probability float64 `json:"probability"`
+	Detected    bool    `json:"detected"`
+	// Delay is the time from the header of the height until the DASer is done with it
+	Delay time.Duration `json:"delay"`
+}
+
+// awaitWithholding waits for the DASer of the light node to be done with every square
+// withheld by the byzantine bridges and publishes how many of them it detected
+func awaitWithholding(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	initCtx *run.InitContext,
+	nd *nodebuilder.Node,
+	p *Params,
+) error {
+	squares, err := getWithheldSquares(ctx, initCtx, p.ByzantineBridges)
+	if err != nil {
+		return err
+	}
+
+	result := testkit.WithholdingResult{GlobalSeq: initCtx.GlobalSeq, Squares: len(squares)}
+	detections := make([]detection, 0, len(squares))
+	for _, sq := range squares {
+		d, err := detect(ctx, nd, sq, p.SampleAmount, p.DetectionDeadline)
+		if err != nil {
+			return fmt.Errorf("height %d: %w", sq.Height, err)
+		}
+
+		result.Expected += d.Probability
+		result.Variance += d.Probability * (1 - d.Probability)
+		if d.Detected {
+			result.Detected++
+			runenv.R().RecordPoint("withholding.detection_ms", float64(d.Delay.Milliseconds()))
+		}
+		runenv.RecordMessage(
+			"Withheld %d out of %d shares at height %d, detected: %t after %s, probability %.4f",
+			sq.Withheld, sq.Width*sq.Width, sq.Height, d.Detected, d.Delay, d.Probability,
+		)
+		detections = append(detections, *d)
+	}
+
+	if result.Squares == 0 {
+		return fmt.Errorf("no withheld square")
+	}
+	runenv.R().RecordPoint("withholding.detection_rate", float64(result.Detected)/float64(result.Squares))
+	runenv.R().RecordPoint("withholding.expected_rate", result.Expected/float64(result.Squares))
+
+	f, err := runenv.CreateRawAsset("withholding.json")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = json.NewEncoder(f).Encode(detections)
+	if err != nil {
+		return err
+	}
+
+	_, err = initCtx.SyncClient.Publish(ctx, testkit.WithholdingResultTopic, &result)
+	return err
+}
+
+// detect waits for the DASer of the light node to be done with the withheld square.
+// The square is detected if the DASer failed to sample it
+func detect(
+	ctx context.Context,
+	nd *nodebuilder.Node,
+	sq byzkit.Square,
+	samples int,
+	deadline time.Duration,
+) (*detection, error) {
+	ctx, cancel := context.WithTimeout(ctx, deadline)
+	defer cancel()
+
+	_, err := nd.HeaderServ.GetByHeight(ctx, sq.Height)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+
+	d := &detection{
+		Height:      sq.Height,
+		Width:       sq.Width,
+		Withheld:    sq.Withheld,
+		Probability: sq.DetectionProbability(samples),
+	}
+	cfg := waitkit.DefaultConfig
+	cfg.Timeout = deadline
+	err = waitkit.Until(ctx, cfg, func(ctx context.Context) (bool, error) {
+		done, failed, err := nodekit.SamplingOutcome(ctx, nd, sq.Height)
+		d.Detected = failed
+		return done, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.Delay = time.Since(start)
+	return d, nil
+}
+
+// checkDetectionRate gathers the results of all the light nodes and fails if their
+// detections deviate from the expected ones by more than detection-z standard deviations.
+// The detections of the light nodes are independent, as none of them can get a withheld
+// share from another, so their sum is approximately normal
+func checkDetectionRate(
+	ctx context.Context,
+	runenv *runtime.RunEnv,
+	initCtx *run.InitContext,
+	p *Params,
+) error {
+	results, err := getWithholdingResults(ctx, initCtx, p.Light)
+	if err != nil {
+		return err
+	}
+
+	var total testkit.WithholdingResult
+	for _, r := range results {
+		total.Squares += r.Squares
+		total.Detected += r.Detected
+		total.Expected += r.Expected
+		total.Variance += r.Variance
+	}
+
+	deviation := float64(total.Detected) - total.Expected
+	sigma := math.Sqrt(total.Variance)
+	rate := float64(total.Detected) / float64(total.Squares)
+	expected := total.Expected / float64(total.Squares)
+	runenv.R().RecordPoint("withholding.total_detection_rate", rate)
+	runenv.R().RecordPoint("withholding.total_expected_rate", expected)
+	if sigma > 0 {
+		runenv.R().RecordPoint("withholding.z", deviation/sigma)
+	}
+	runenv.RecordMessage(
+		"Light nodes detected %d out of %d withheld squares (%.4f), expected %.2f ± %.2f (%.4f)",
+		total.Detected, total.Squares, rate, total.Expected, sigma, expected,
+	)
+
+	f, err := runenv.CreateRawAsset("withholding-summary.json")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = json.NewEncoder(f).Encode(struct {
+		Results  []*testkit.WithholdingResult `json:"results"`
+		Expected float64                      `json:"expected"`
+		Sigma    float64                      `json:"sigma"`
+	}{results, total.Expected, sigma})
+	if err != nil {
+		return err
+	}
+
+	// the detections are counted, so a deviation below half a detection is always accepted
+	if math.Abs(deviation) >= 0.5 && math.Abs(deviation) > p.DetectionZ*sigma {
+		return fmt.Errorf(
+			"light nodes detected %d withheld squares, expected %.2f ± %.2f at %v standard deviations",
+			total.Detected, total.Expected, p.DetectionZ*sigma, p.DetectionZ,
+		)
+	}
+	return nil
+}
+
+// getWithheldSquares returns the squares withheld by all the byzantine bridges, sorted by height.
+// The bridges withhold the same shares of the same squares, so every height is returned once
+func getWithheldSquares(ctx context.Context, initCtx *run.InitContext, bridges int) ([]byzkit.Square, error) {
+	reports, err := common.GetByzantineReports(ctx, initCtx.SyncClient, bridges)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		squares []byzkit.Square
+		seen    = make(map[uint64]bool)
+	)
+	for _, r := range reports {
+		for _, sq := range r.Squares {
+			if !seen[sq.Height] {
+				seen[sq.Height] = true
+				squares = append(squares, sq)
+			}
+		}
+	}
+
+	sort.Slice(squares, func(i, j int) bool { return squares[i].Height < squares[j].Height })
+	return squares, nil
+}
+
+// getWithholdingResults waits for the results of all the light nodes
+func getWithholdingResults(
+	ctx context.Context,
+	initCtx *run.InitContext,
+	lights int,
+) ([]*testkit.WithholdingResult, error) {
+	resultCh := make(chan *testkit.WithholdingResult)
+	sub, err := initCtx.SyncClient.Subscribe(ctx, testkit.WithholdingResultTopic, resultCh)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		results []*testkit.WithholdingResult
+		seen    = make(map[int64]bool)
+	)
+	for len(results) < lights {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("received %d out of %d withholding results: %w", len(results), lights, ctx.Err())
+		case err = <-sub.Done():
+			if err != nil {
+				return nil, err
+			}
+		case r := <-resultCh:
+			if !seen[r.GlobalSeq] {
+				seen[r.GlobalSeq] = true
+				results = append(results, r)
+			}
+		}
+	}
+	return results, nil
+}
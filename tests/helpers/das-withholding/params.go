@@ -0,0 +1,53 @@
+package daswithholding
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/test-infra/testkit/byzkit"
+	"github.com/celestiaorg/test-infra/tests/helpers/common"
+)
+
+// Params of the test-cases where light nodes sample squares partially withheld
+// by byzantine bridges, e.g. das-withholding
+type Params struct {
+	common.Topology
+	common.PeerGraph
+	common.Genesis
+	common.Execution
+	common.Submission
+	common.Assignment
+	common.Byzantine
+	// SampleAmount is the amount of shares every light node samples per square
+	SampleAmount int `param:"sample-amount" default:"16"`
+	// DetectionZ is how many standard deviations the detections of all the light
+	// nodes may deviate from the expected ones
+	DetectionZ float64 `param:"detection-z" default:"3"`
+}
+
+func (p *Params) Validate() error {
+	var attack, z error
+	if p.Attack != string(byzkit.Withhold) {
+		attack = fmt.Errorf("attack must be %s, got %s", byzkit.Withhold, p.Attack)
+	}
+	if p.DetectionZ <= 0 {
+		z = fmt.Errorf("detection-z must be > 0, got %v", p.DetectionZ)
+	}
+	return errors.Join(
+		p.Topology.Validate(),
+		p.PeerGraph.Validate(),
+		p.Genesis.Validate(),
+		p.Execution.Validate(),
+		p.Submission.Validate(),
+		p.Assignment.Validate(),
+		p.Byzantine.Validate(),
+		attack,
+		z,
+		common.AtLeast("validator", p.Validator, 1),
+		common.AtLeast("light", p.Light, 1),
+		common.AtLeast("sample-amount", p.SampleAmount, 1),
+		// the honest bridges and the full nodes would serve the withheld shares
+		common.AtMost("bridge", p.Bridge, p.ByzantineBridges),
+		common.AtMost("full", p.Full, 0),
+	)
+}
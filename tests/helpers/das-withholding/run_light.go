@@ -0,0 +1,100 @@
+package daswithholding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+
+	"github.com/celestiaorg/test-infra/testkit"
+	"github.com/celestiaorg/test-infra/testkit/netkit"
+	"github.com/celestiaorg/test-infra/testkit/nodekit"
+	"github.com/celestiaorg/test-infra/testkit/paramkit"
+	"github.com/celestiaorg/test-infra/tests/helpers/common"
+)
+
+// RunLightNode runs a light node sampling sample-amount shares of every square from
+// its assigned bridge, which records the withheld squares it detects, see awaitWithholding.
+// The first light node also checks the detections of all of them, see checkDetectionRate
+func RunLightNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		time.Minute*time.Duration(runenv.IntParam("execution-time")),
+	)
+	defer cancel()
+
+	err := nodekit.SetLoggersLevel("INFO")
+	if err != nil {
+		return err
+	}
+
+	syncclient := initCtx.SyncClient
+
+	_, err = netkit.ConfigureNetwork(ctx, runenv, initCtx)
+	if err != nil {
+		return err
+	}
+
+	var p Params
+	err = paramkit.Load(runenv, &p)
+	if err != nil {
+		return err
+	}
+
+	bridgeNode, err := common.AssignBridge(ctx, runenv, initCtx, p.Bridge)
+	if err != nil {
+		return err
+	}
+
+	ndhome := fmt.Sprintf("/.celestia-light-%d", initCtx.GlobalSeq)
+	runenv.RecordMessage(ndhome)
+
+	ip, err := initCtx.NetClient.GetDataNetworkIP()
+	if err != nil {
+		return err
+	}
+
+	trustedPeers := []string{bridgeNode.Maddr}
+	cfg := nodekit.NewConfig(node.Light, ip, trustedPeers, bridgeNode.TrustedHash)
+	cfg.Share.LightAvailability.SampleAmount = uint(p.SampleAmount)
+	nd, err := nodekit.NewNode(ndhome, node.Light, runenv.StringParam("p2p-network"), cfg)
+	if err != nil {
+		return err
+	}
+
+	err = nd.Start(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = awaitWithholding(ctx, runenv, initCtx, nd, &p)
+	// the bridges keep serving until every light node is done with the withheld squares
+	_, signalErr := syncclient.SignalEntry(ctx, testkit.ByzantineDetectedState)
+	err = errors.Join(err, signalErr)
+	if err != nil {
+		return err
+	}
+
+	if initCtx.GroupSeq == 1 {
+		err = checkDetectionRate(ctx, runenv, initCtx, &p)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = nd.Stop(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = syncclient.SignalEntry(ctx, testkit.FinishState)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
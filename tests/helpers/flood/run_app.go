@@ -4,8 +4,9 @@ import (
 	"context"
 	"github.com/celestiaorg/test-infra/testkit"
 	"github.com/celestiaorg/test-infra/testkit/appkit"
+	"github.com/celestiaorg/test-infra/testkit/netkit"
+	"github.com/celestiaorg/test-infra/testkit/waitkit"
 	"github.com/celestiaorg/test-infra/tests/helpers/common"
-	"github.com/testground/sdk-go/network"
 	"github.com/testground/sdk-go/run"
 	"github.com/testground/sdk-go/runtime"
 	"net"
@@ -20,30 +21,8 @@ func RunAppValidator(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 	defer cancel()
 
 	syncclient := initCtx.SyncClient
-	netclient := network.NewClient(syncclient, runenv)
 
-	netclient.MustWaitNetworkInitialized(ctx)
-
-	config := network.Config{
-		Network: "default",
-		Enable:  true,
-		Default: network.LinkShape{
-			Latency:   time.Duration(runenv.IntParam("latency")),
-			Bandwidth: common.GetBandwidthValue(runenv.StringParam("bandwidth")),
-		},
-		CallbackState: "network-configured",
-		RoutingPolicy: network.AllowAll,
-	}
-
-	config.IPv4 = runenv.TestSubnet
-
-	// using the assigned `GlobalSequencer` id per each of instance
-	// to fill in the last 2 octets of the new IP address for the instance
-	ipC := byte((initCtx.GlobalSeq >> 8) + 1)
-	ipD := byte(initCtx.GlobalSeq)
-	config.IPv4.IP = append(config.IPv4.IP[0:2:2], ipC, ipD)
-
-	err := netclient.ConfigureNetwork(ctx, &config)
+	_, err := netkit.ConfigureNetwork(ctx, runenv, initCtx)
 	if err != nil {
 		return err
 	}
@@ -54,7 +33,7 @@ func RunAppValidator(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 	}
 
 	if initCtx.GroupSeq == 1 {
-		ip, err := netclient.GetDataNetworkIP()
+		ip, err := initCtx.NetClient.GetDataNetworkIP()
 		if err != nil {
 			return err
 		}
@@ -72,8 +51,11 @@ func RunAppValidator(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 		go appcmd.StartNode("info")
 	}
 
-	// wait for a new block to be produced
-	time.Sleep(1 * time.Minute)
+	// wait for the first block to be produced and gRPC to be served
+	err = waitkit.ForAppNode(ctx, net.ParseIP("127.0.0.1"))
+	if err != nil {
+		return err
+	}
 
 	_, err = syncclient.SignalEntry(ctx, "validator-ready")
 	if err != nil {
@@ -86,29 +68,26 @@ func RunAppValidator(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 		return err
 	}
 
-	var prefix int
-	if runenv.TestGroupID == "validators-v2" {
-		prefix = 50
-	} else {
-		prefix = 0
-	}
-
-	appId := int(initCtx.GroupSeq) + prefix
+	// the global sequence number keeps the ID unique across the groups of validators
+	appId := int(initCtx.GlobalSeq)
 	_, err = syncclient.Publish(
 		ctx,
 		testkit.AppNodeTopic,
 		&testkit.AppNodeInfo{
-			ID: appId,
-			IP: ip,
+			ID:   appId,
+			IP:   ip,
+			Zone: common.InstanceZone(runenv),
 		},
 	)
 	if err != nil {
 		return err
 	}
 
+	r := common.InstanceRand(runenv, initCtx, "blobs")
 	for i := 0; i < runenv.IntParam("submit-times"); i++ {
 		runenv.RecordMessage("Submitting PFD with %d bytes random data", runenv.IntParam("msg-size"))
-		err = appcmd.PayForBlob(
+		res, err := appcmd.PayForBlob(
+			r,
 			appcmd.AccountAddress,
 			runenv.IntParam("msg-size"),
 			"test",
@@ -118,6 +97,7 @@ func RunAppValidator(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 			runenv.RecordFailure(err)
 			return err
 		}
+		res.Record(runenv)
 
 		s, err := appkit.GetLatestsBlockSize(net.ParseIP("127.0.0.1"))
 		if err != nil {
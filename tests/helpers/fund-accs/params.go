@@ -0,0 +1,32 @@
+package fundaccounts
+
+import (
+	"errors"
+
+	"github.com/celestiaorg/test-infra/tests/helpers/common"
+)
+
+// Params of the test-cases where the DA nodes submit PFBs from
+// their funded accounts, e.g. pay-for-blob
+type Params struct {
+	common.Topology
+	common.PeerGraph
+	common.Genesis
+	common.Execution
+	common.Submission
+	common.Assignment
+	NamespaceID string `param:"namespace-id" default:"1"`
+}
+
+func (p *Params) Validate() error {
+	return errors.Join(
+		p.Topology.Validate(),
+		p.PeerGraph.Validate(),
+		p.Genesis.Validate(),
+		p.Execution.Validate(),
+		p.Submission.Validate(),
+		p.Assignment.Validate(),
+		common.AtLeast("validator", p.Validator, 1),
+		common.AtLeast("bridge", p.Bridge, 1),
+	)
+}
@@ -6,9 +6,9 @@ import (
 	"time"
 
 	"github.com/celestiaorg/test-infra/testkit"
+	"github.com/celestiaorg/test-infra/testkit/netkit"
 	"github.com/celestiaorg/test-infra/testkit/nodekit"
 	"github.com/celestiaorg/test-infra/tests/helpers/common"
-	"github.com/testground/sdk-go/network"
 	"github.com/testground/sdk-go/run"
 	"github.com/testground/sdk-go/runtime"
 )
@@ -26,30 +26,8 @@ func RunBridgeNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 	}
 
 	syncclient := initCtx.SyncClient
-	netclient := network.NewClient(syncclient, runenv)
-
-	netclient.MustWaitNetworkInitialized(ctx)
-
-	config := network.Config{
-		Network: "default",
-		Enable:  true,
-		Default: network.LinkShape{
-			Latency:   time.Duration(runenv.IntParam("latency")),
-			Bandwidth: common.GetBandwidthValue(runenv.StringParam("bandwidth")),
-		},
-		CallbackState: "network-configured",
-		RoutingPolicy: network.AllowAll,
-	}
-
-	config.IPv4 = runenv.TestSubnet
-
-	// using the assigned `GlobalSequencer` id per each of instance
-	// to fill in the last 2 octets of the new IP address for the instance
-	ipC := byte((initCtx.GlobalSeq >> 8) + 1)
-	ipD := byte(initCtx.GlobalSeq)
-	config.IPv4.IP = append(config.IPv4.IP[0:2:2], ipC, ipD)
 
-	err = netclient.ConfigureNetwork(ctx, &config)
+	_, err = netkit.ConfigureNetwork(ctx, runenv, initCtx)
 	if err != nil {
 		return err
 	}
@@ -102,17 +80,29 @@ func RunBridgeNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 
 	runenv.RecordMessage("bridge -> %d has this %s balance", initCtx.GroupSeq, bal.String())
 
-	nid := common.GenerateNamespaceID(runenv.StringParam("namespace-id"))
-	data := common.GetRandomMessageBySize(runenv.IntParam("msg-size"))
-
-	for i := 0; i < runenv.IntParam("submit-times"); i++ {
-		err = common.SubmitData(ctx, runenv, nd, nid, data)
+	if runenv.IsParamSet("blobs") {
+		err = common.SubmitPlannedBlobs(ctx, runenv, nd, common.InstanceRand(runenv, initCtx, "blobs"))
 		if err != nil {
 			return err
 		}
-
-		if runenv.TestCase == "get-shares-by-namespace" && common.VerifyDataInNamespace(ctx, nd, nid, data) != nil {
-			return fmt.Errorf("no expected data found in the namespace ID")
+	} else {
+		r := common.InstanceRand(runenv, initCtx, "data")
+		nid := common.GenerateNamespaceID(r, runenv.StringParam("namespace-id"))
+		data := common.GetRandomMessageBySize(r, runenv.IntParam("msg-size"))
+
+		for i := 0; i < runenv.IntParam("submit-times"); i++ {
+			res, err := common.SubmitData(ctx, runenv, nd, nid, data)
+			if err != nil {
+				return err
+			}
+
+			if runenv.TestCase == "get-shares-by-namespace" {
+				h, err := common.VerifyDataInNamespace(ctx, nd, nid, data, uint64(res.Height), common.DefaultInclusionWindow)
+				if err != nil {
+					return fmt.Errorf("no expected data found in the namespace ID: %w", err)
+				}
+				runenv.RecordMessage("data of pfb %s found in the namespace ID at height %d", res.TxHash, h)
+			}
 		}
 	}
 
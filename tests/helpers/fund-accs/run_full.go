@@ -7,9 +7,9 @@ import (
 
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/test-infra/testkit"
+	"github.com/celestiaorg/test-infra/testkit/netkit"
 	"github.com/celestiaorg/test-infra/testkit/nodekit"
 	"github.com/celestiaorg/test-infra/tests/helpers/common"
-	"github.com/testground/sdk-go/network"
 	"github.com/testground/sdk-go/run"
 	"github.com/testground/sdk-go/runtime"
 )
@@ -27,46 +27,19 @@ func RunFullNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 	}
 
 	syncclient := initCtx.SyncClient
-	netclient := network.NewClient(syncclient, runenv)
 
-	netclient.MustWaitNetworkInitialized(ctx)
-
-	config := network.Config{
-		Network: "default",
-		Enable:  true,
-		Default: network.LinkShape{
-			Latency:   time.Duration(runenv.IntParam("latency")),
-			Bandwidth: common.GetBandwidthValue(runenv.StringParam("bandwidth")),
-		},
-		CallbackState: "network-configured",
-		RoutingPolicy: network.AllowAll,
-	}
-
-	config.IPv4 = runenv.TestSubnet
-
-	// using the assigned `GlobalSequencer` id per each of instance
-	// to fill in the last 2 octets of the new IP address for the instance
-	ipC := byte((initCtx.GlobalSeq >> 8) + 1)
-	ipD := byte(initCtx.GlobalSeq)
-	config.IPv4.IP = append(config.IPv4.IP[0:2:2], ipC, ipD)
-
-	err = netclient.ConfigureNetwork(ctx, &config)
+	_, err = netkit.ConfigureNetwork(ctx, runenv, initCtx)
 	if err != nil {
 		return err
 	}
 
 	// we need to get the validator's ip in info for grpc connection for pfd & gsbn
-	appNode, err := common.GetValidatorInfo(ctx, syncclient, runenv.IntParam("validator"), int(initCtx.GroupSeq))
+	appNode, err := common.AssignValidator(ctx, runenv, initCtx, runenv.IntParam("validator"))
 	if err != nil {
 		return err
 	}
 
-	bridgeNode, err := common.GetBridgeNode(
-		ctx,
-		syncclient,
-		initCtx.GroupSeq,
-		runenv.IntParam("bridge"),
-	)
+	bridgeNode, err := common.AssignBridge(ctx, runenv, initCtx, runenv.IntParam("bridge"))
 	if err != nil {
 		return err
 	}
@@ -87,6 +60,11 @@ func RunFullNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 	cfg.Gateway.Enabled = true
 	cfg.Gateway.Port = "26659"
 
+	err = common.UsePrefundedKey(runenv, initCtx, ndhome, cfg)
+	if err != nil {
+		return err
+	}
+
 	nd, err := nodekit.NewNode(ndhome, node.Full, runenv.StringParam("p2p-network"), cfg)
 	if err != nil {
 		return err
@@ -135,17 +113,29 @@ func RunFullNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 
 	runenv.RecordMessage("full -> %d has this %s balance", initCtx.GroupSeq, bal.String())
 
-	nid := common.GenerateNamespaceID(runenv.StringParam("namespace-id"))
-	data := common.GetRandomMessageBySize(runenv.IntParam("msg-size"))
-
-	for i := 0; i < runenv.IntParam("submit-times"); i++ {
-		err = common.SubmitData(ctx, runenv, nd, nid, data)
+	if runenv.IsParamSet("blobs") {
+		err = common.SubmitPlannedBlobs(ctx, runenv, nd, common.InstanceRand(runenv, initCtx, "blobs"))
 		if err != nil {
 			return err
 		}
-
-		if runenv.TestCase == "get-shares-by-namespace" && common.VerifyDataInNamespace(ctx, nd, nid, data) != nil {
-			return fmt.Errorf("no expected data found in the namespace ID")
+	} else {
+		r := common.InstanceRand(runenv, initCtx, "data")
+		nid := common.GenerateNamespaceID(r, runenv.StringParam("namespace-id"))
+		data := common.GetRandomMessageBySize(r, runenv.IntParam("msg-size"))
+
+		for i := 0; i < runenv.IntParam("submit-times"); i++ {
+			res, err := common.SubmitData(ctx, runenv, nd, nid, data)
+			if err != nil {
+				return err
+			}
+
+			if runenv.TestCase == "get-shares-by-namespace" {
+				h, err := common.VerifyDataInNamespace(ctx, nd, nid, data, uint64(res.Height), common.DefaultInclusionWindow)
+				if err != nil {
+					return fmt.Errorf("no expected data found in the namespace ID: %w", err)
+				}
+				runenv.RecordMessage("data of pfb %s found in the namespace ID at height %d", res.TxHash, h)
+			}
 		}
 	}
 
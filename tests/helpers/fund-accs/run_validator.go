@@ -12,9 +12,10 @@ import (
 	"time"
 
 	"github.com/celestiaorg/test-infra/testkit"
+	"github.com/celestiaorg/test-infra/testkit/netkit"
+	"github.com/celestiaorg/test-infra/testkit/waitkit"
 	"github.com/celestiaorg/test-infra/tests/helpers/common"
 
-	"github.com/testground/sdk-go/network"
 	"github.com/testground/sdk-go/run"
 	"github.com/testground/sdk-go/runtime"
 )
@@ -27,30 +28,8 @@ func RunAppValidator(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 	defer cancel()
 
 	syncclient := initCtx.SyncClient
-	netclient := network.NewClient(syncclient, runenv)
 
-	netclient.MustWaitNetworkInitialized(ctx)
-
-	config := network.Config{
-		Network: "default",
-		Enable:  true,
-		Default: network.LinkShape{
-			Latency:   time.Duration(runenv.IntParam("latency")),
-			Bandwidth: common.GetBandwidthValue(runenv.StringParam("bandwidth")),
-		},
-		CallbackState: "network-configured",
-		RoutingPolicy: network.AllowAll,
-	}
-
-	config.IPv4 = runenv.TestSubnet
-
-	// using the assigned `GlobalSequencer` id per each of instance
-	// to fill in the last 2 octets of the new IP address for the instance
-	ipC := byte((initCtx.GlobalSeq >> 8) + 1)
-	ipD := byte(initCtx.GlobalSeq)
-	config.IPv4.IP = append(config.IPv4.IP[0:2:2], ipC, ipD)
-
-	err := netclient.ConfigureNetwork(ctx, &config)
+	_, err := netkit.ConfigureNetwork(ctx, runenv, initCtx)
 	if err != nil {
 		return err
 	}
@@ -61,7 +40,7 @@ func RunAppValidator(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 	}
 
 	if initCtx.GroupSeq == 1 {
-		ip, err := netclient.GetDataNetworkIP()
+		ip, err := initCtx.NetClient.GetDataNetworkIP()
 		if err != nil {
 			return err
 		}
@@ -103,8 +82,11 @@ func RunAppValidator(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 		go appcmd.StartNode("info")
 	}
 
-	// wait for a new block to be produced
-	time.Sleep(1 * time.Minute)
+	// wait for the first block to be produced and gRPC to be served
+	err = waitkit.ForAppNode(ctx, net.ParseIP("127.0.0.1"))
+	if err != nil {
+		return err
+	}
 
 	_, err = syncclient.SignalEntry(ctx, "validator-ready")
 	if err != nil {
@@ -122,8 +104,9 @@ func RunAppValidator(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 		ctx,
 		testkit.AppNodeTopic,
 		&testkit.AppNodeInfo{
-			ID: appId,
-			IP: ip,
+			ID:   appId,
+			IP:   ip,
+			Zone: common.InstanceZone(runenv),
 		},
 	)
 	if err != nil {
@@ -0,0 +1,37 @@
+package nodesync
+
+import (
+	"errors"
+
+	"github.com/celestiaorg/test-infra/tests/helpers/common"
+)
+
+// Params of the test-cases where bridge, full and light nodes sync
+// the blocks produced by the validators, e.g. 002-da-sync
+type Params struct {
+	common.Topology
+	common.PeerGraph
+	common.Genesis
+	common.Execution
+	common.Submission
+	common.Assignment
+	common.Sampling
+	common.Chaos
+	common.Partition
+}
+
+func (p *Params) Validate() error {
+	return errors.Join(
+		p.Topology.Validate(),
+		p.PeerGraph.Validate(),
+		p.Genesis.Validate(),
+		p.Execution.Validate(),
+		p.Submission.Validate(),
+		p.Assignment.Validate(),
+		p.Sampling.Validate(),
+		p.Chaos.Validate(),
+		p.Partition.Validate(),
+		common.AtLeast("validator", p.Validator, 1),
+		common.AtLeast("bridge", p.Bridge, 1),
+	)
+}
@@ -6,9 +6,9 @@ import (
 	"time"
 
 	"github.com/celestiaorg/test-infra/testkit"
+	"github.com/celestiaorg/test-infra/testkit/netkit"
 	"github.com/celestiaorg/test-infra/testkit/nodekit"
 	"github.com/celestiaorg/test-infra/tests/helpers/common"
-	"github.com/testground/sdk-go/network"
 	"github.com/testground/sdk-go/run"
 	"github.com/testground/sdk-go/runtime"
 )
@@ -26,35 +26,23 @@ func RunBridgeNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 	}
 
 	syncclient := initCtx.SyncClient
-	netclient := network.NewClient(syncclient, runenv)
 
-	netclient.MustWaitNetworkInitialized(ctx)
-
-	config := network.Config{
-		Network: "default",
-		Enable:  true,
-		Default: network.LinkShape{
-			Latency:   time.Duration(runenv.IntParam("latency")),
-			Bandwidth: common.GetBandwidthValue(runenv.StringParam("bandwidth")),
-		},
-		CallbackState: "network-configured",
-		RoutingPolicy: network.AllowAll,
+	_, err = netkit.ConfigureNetwork(ctx, runenv, initCtx)
+	if err != nil {
+		return err
 	}
 
-	config.IPv4 = runenv.TestSubnet
-
-	// using the assigned `GlobalSequencer` id per each of instance
-	// to fill in the last 2 octets of the new IP address for the instance
-	ipC := byte((initCtx.GlobalSeq >> 8) + 1)
-	ipD := byte(initCtx.GlobalSeq)
-	config.IPv4.IP = append(config.IPv4.IP[0:2:2], ipC, ipD)
+	target, err := common.BuildRestartableBridge(ctx, runenv, initCtx)
+	if err != nil {
+		return err
+	}
 
-	err = netclient.ConfigureNetwork(ctx, &config)
+	nd, err := common.CrashNode(ctx, runenv, initCtx, "bridge", target)
 	if err != nil {
 		return err
 	}
 
-	nd, err := common.BuildBridge(ctx, runenv, initCtx)
+	err = common.RunPartition(ctx, runenv, initCtx, "bridge", target)
 	if err != nil {
 		return err
 	}
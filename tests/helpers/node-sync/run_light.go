@@ -3,15 +3,17 @@ package nodesync
 import (
 	"context"
 	"fmt"
+	"github.com/celestiaorg/celestia-node/das"
 	"github.com/celestiaorg/celestia-node/nodebuilder"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"time"
 
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/test-infra/testkit"
+	"github.com/celestiaorg/test-infra/testkit/faultkit"
+	"github.com/celestiaorg/test-infra/testkit/netkit"
 	"github.com/celestiaorg/test-infra/testkit/nodekit"
 	"github.com/celestiaorg/test-infra/tests/helpers/common"
-	"github.com/testground/sdk-go/network"
 	"github.com/testground/sdk-go/run"
 	"github.com/testground/sdk-go/runtime"
 )
@@ -29,35 +31,13 @@ func RunLightNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 	}
 
 	syncclient := initCtx.SyncClient
-	netclient := network.NewClient(syncclient, runenv)
 
-	netclient.MustWaitNetworkInitialized(ctx)
-
-	config := network.Config{
-		Network: "default",
-		Enable:  true,
-		Default: network.LinkShape{
-			Latency:   time.Duration(runenv.IntParam("latency")),
-			Bandwidth: common.GetBandwidthValue(runenv.StringParam("bandwidth")),
-		},
-		CallbackState: "network-configured",
-		RoutingPolicy: network.AllowAll,
-	}
-
-	config.IPv4 = runenv.TestSubnet
-
-	// using the assigned `GlobalSequencer` id per each of instance
-	// to fill in the last 2 octets of the new IP address for the instance
-	ipC := byte((initCtx.GlobalSeq >> 8) + 1)
-	ipD := byte(initCtx.GlobalSeq)
-	config.IPv4.IP = append(config.IPv4.IP[0:2:2], ipC, ipD)
-
-	err = netclient.ConfigureNetwork(ctx, &config)
+	_, err = netkit.ConfigureNetwork(ctx, runenv, initCtx)
 	if err != nil {
 		return err
 	}
 
-	bridgeNode, err := common.GetBridgeNode(ctx, syncclient, initCtx.GroupSeq, runenv.IntParam("bridge"))
+	bridgeNode, err := common.AssignBridge(ctx, runenv, initCtx, runenv.IntParam("bridge"))
 	if err != nil {
 		return err
 	}
@@ -76,20 +56,40 @@ func RunLightNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 	}
 
 	cfg := nodekit.NewConfig(node.Light, ip, trustedPeers, bridgeNode.TrustedHash)
-	nd, err := nodekit.NewNode(ndhome, node.Light, runenv.StringParam("p2p-network"), cfg,
-		nodebuilder.WithMetrics(
-			optlOpts,
-			node.Light,
-		))
+	target, err := faultkit.NewNode(func() (*nodebuilder.Node, nodebuilder.Store, error) {
+		return nodekit.OpenNode(ndhome, node.Light, runenv.StringParam("p2p-network"), cfg,
+			nodebuilder.WithMetrics(
+				optlOpts,
+				node.Light,
+			))
+	})
 	if err != nil {
 		return err
 	}
 
+	nd := target.Node()
 	err = nd.Start(ctx)
 	if err != nil {
 		return err
 	}
 
+	sampling, err := common.CollectSampling(ctx, runenv, func(ctx context.Context) (das.SamplingStats, error) {
+		return target.Node().DASer.SamplingStats(ctx)
+	})
+	if err != nil {
+		return err
+	}
+
+	nd, err = common.CrashNode(ctx, runenv, initCtx, "light", target)
+	if err != nil {
+		return err
+	}
+
+	err = common.RunPartition(ctx, runenv, initCtx, "light", target)
+	if err != nil {
+		return err
+	}
+
 	eh, err := nd.HeaderServ.GetByHeight(ctx, uint64(10))
 	if err != nil {
 		return err
@@ -110,6 +110,11 @@ func RunLightNode(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
 		runenv.RecordFailure(fmt.Errorf("full node is still syncing the past"))
 	}
 
+	err = common.StopSampling(ctx, runenv, sampling)
+	if err != nil {
+		return err
+	}
+
 	err = nd.Stop(ctx)
 	if err != nil {
 		return err
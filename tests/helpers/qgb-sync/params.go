@@ -0,0 +1,31 @@
+package appsync
+
+import (
+	"errors"
+
+	"github.com/celestiaorg/test-infra/tests/helpers/common"
+)
+
+// Params of the QGB test-case, where the validators run orchestrators
+// and relayers next to them
+type Params struct {
+	common.Topology
+	common.PeerGraph
+	common.Genesis
+	common.Execution
+	common.Submission
+	EVMRPC              string `param:"evm-rpc" default:""`
+	ChainID             string `param:"chain-id" default:""`
+	FundedEVMPrivateKey string `param:"funded-evm-private-key" default:""`
+}
+
+func (p *Params) Validate() error {
+	return errors.Join(
+		p.Topology.Validate(),
+		p.PeerGraph.Validate(),
+		p.Genesis.Validate(),
+		p.Execution.Validate(),
+		p.Submission.Validate(),
+		common.AtLeast("validator", p.Validator, 1),
+	)
+}
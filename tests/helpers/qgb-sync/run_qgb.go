@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"github.com/celestiaorg/test-infra/testkit"
 	"github.com/celestiaorg/test-infra/testkit/appkit"
+	"github.com/celestiaorg/test-infra/testkit/netkit"
 	"github.com/celestiaorg/test-infra/testkit/qgbkit"
+	"github.com/celestiaorg/test-infra/testkit/waitkit"
 	appsync "github.com/celestiaorg/test-infra/tests/helpers/app-sync"
 	"github.com/celestiaorg/test-infra/tests/helpers/common"
 	common2 "github.com/ethereum/go-ethereum/common"
@@ -13,6 +15,7 @@ import (
 	"github.com/testground/sdk-go/network"
 	"github.com/testground/sdk-go/run"
 	"github.com/testground/sdk-go/runtime"
+	"net"
 	"strings"
 	"time"
 )
@@ -35,8 +38,10 @@ func RunValidatorWithOrchestrator(runenv *runtime.RunEnv, initCtx *run.InitConte
 	go RunValidatorWithEVMAddress(runenv, initCtx, common.ECDSAToAddress(orchcmd.EVMPrivateKey))
 
 	runenv.RecordMessage("waiting for validator to start......")
-	// wait for the validator to start
-	time.Sleep(1 * time.Minute)
+	err = waitkit.ForAppNode(ctx, net.ParseIP("127.0.0.1"))
+	if err != nil {
+		return err
+	}
 
 	if initCtx.GroupSeq == 1 {
 		ip, err := netclient.GetDataNetworkIP()
@@ -69,10 +74,14 @@ func RunValidatorWithOrchestrator(runenv *runtime.RunEnv, initCtx *run.InitConte
 			return err
 		}
 
-		var bootstrappers []string
+		var (
+			bootstrappers []string
+			addrs         []string
+		)
 		for i := 0; i < 1; i++ {
 			select {
 			case bootstrapper := <-bootstrapperCh:
+				addrs = append(addrs, net.JoinHostPort(bootstrapper.IP.To4().String(), "30000"))
 				// to the format /ip4/127.0.0.1/tcp/30000/p2p/12D3KooWQKobCvC2jms83hGeer8iSSxcxSKa9x7RyWMTKdTKoNvH
 				bootstrappers = append(bootstrappers, fmt.Sprintf(
 					"/ip4/%s/tcp/30000/p2p/%s",
@@ -83,7 +92,12 @@ func RunValidatorWithOrchestrator(runenv *runtime.RunEnv, initCtx *run.InitConte
 		}
 
 		runenv.RecordMessage("waiting for bootstrapper node to be up")
-		time.Sleep(time.Minute)
+		for _, addr := range addrs {
+			err = waitkit.ForTCP(ctx, addr)
+			if err != nil {
+				return err
+			}
+		}
 		go orchcmd.StartOrchestrator(common.ECDSAToAddress(orchcmd.EVMPrivateKey).Hex(), common.EVMPrivateKeyPassphrase, "", strings.Join(bootstrappers, ","))
 	}
 
@@ -114,8 +128,10 @@ func RunValidatorWithRelayer(runenv *runtime.RunEnv, initCtx *run.InitContext) e
 	go RunValidatorWithEVMAddress(runenv, initCtx, common.ECDSAToAddress(relCmd.EVMPrivateKey))
 
 	runenv.RecordMessage("waiting for validator to start......")
-	// wait for the validator to start
-	time.Sleep(1 * time.Minute)
+	err = waitkit.ForAppNode(ctx, net.ParseIP("127.0.0.1"))
+	if err != nil {
+		return err
+	}
 
 	runenv.RecordMessage("getting bootstrappers information........")
 	bootstrapperCh := make(chan *qgbkit.BootstrapperNode)
@@ -148,28 +164,31 @@ func RunValidatorWithRelayer(runenv *runtime.RunEnv, initCtx *run.InitContext) e
 		return fmt.Errorf("invalid EVM RPC. please set it in configuration")
 	}
 
-	// to give time for validators to register their EVM addresses
-	time.Sleep(time.Minute)
+	// the contract is deployed with the valset of the validators having registered their EVM addresses
+	runenv.RecordMessage("waiting for the validators to register their EVM addresses")
+	err = waitkit.ForBarrier(ctx, syncclient, testkit.EVMAddressRegisteredState, runenv.IntParam("validator"))
+	if err != nil {
+		return err
+	}
 
-	retries := 0
 	var addr string
-	for {
+	cfg := waitkit.DefaultConfig
+	cfg.Timeout = time.Minute
+	err = waitkit.Until(ctx, cfg, func(context.Context) (bool, error) {
 		addr, err = relCmd.DeployContract(
 			common.ECDSAToAddress(relCmd.EVMPrivateKey).Hex(),
 			common.EVMPrivateKeyPassphrase,
 			chainID,
 			evmRPC,
 		)
-		if err == nil {
-			break
-		}
-		if retries > 5 {
-			return err
+		if err != nil {
+			runenv.RecordMessage("deploying contract: %s", err)
+			return false, err
 		}
-		runenv.RecordMessage(err.Error())
-		retries++
-		time.Sleep(10 * time.Second)
-		fmt.Println("retrying deploying contract")
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("deploying contract: %w", err)
 	}
 
 	runenv.RecordMessage("contract deployed %s", addr)
@@ -203,13 +222,8 @@ func RunValidatorWithEVMAddress(runenv *runtime.RunEnv, initCtx *run.InitContext
 	defer cancel()
 
 	syncclient := initCtx.SyncClient
-	netclient := network.NewClient(syncclient, runenv)
-
-	netclient.MustWaitNetworkInitialized(ctx)
 
-	config := appsync.CreateConfig(runenv, initCtx)
-
-	err := netclient.ConfigureNetwork(ctx, &config)
+	_, err := netkit.ConfigureNetwork(ctx, runenv, initCtx)
 	if err != nil {
 		return err
 	}
@@ -220,7 +234,7 @@ func RunValidatorWithEVMAddress(runenv *runtime.RunEnv, initCtx *run.InitContext
 	}
 
 	if initCtx.GroupSeq == 1 {
-		ip, err := netclient.GetDataNetworkIP()
+		ip, err := initCtx.NetClient.GetDataNetworkIP()
 		if err != nil {
 			return err
 		}
@@ -243,16 +257,25 @@ func RunValidatorWithEVMAddress(runenv *runtime.RunEnv, initCtx *run.InitContext
 		go appcmd.StartNode("error")
 	}
 
-	// wait for a new block to be produced
-	time.Sleep(2 * time.Minute)
+	// wait for the first block to be produced and gRPC to be served
+	err = waitkit.ForAppNode(ctx, net.ParseIP("127.0.0.1"))
+	if err != nil {
+		return err
+	}
 
-	for {
+	cfg := waitkit.DefaultConfig
+	cfg.Timeout = time.Minute
+	err = waitkit.Until(ctx, cfg, func(context.Context) (bool, error) {
 		err := RegisterEVMAddress(runenv, appcmd, evmAddr)
-		if err == nil {
-			break
-		}
-		runenv.RecordFailure(err)
-		time.Sleep(10 * time.Second)
+		return err == nil, err
+	})
+	if err != nil {
+		return fmt.Errorf("registering EVM address: %w", err)
+	}
+
+	_, err = syncclient.SignalEntry(ctx, testkit.EVMAddressRegisteredState)
+	if err != nil {
+		return err
 	}
 
 	// keep the validator running long enough for attestations to get signed
@@ -1,34 +1,22 @@
 package blockrecon
 
 import (
-	"context"
 	"github.com/celestiaorg/test-infra/testkit"
 	"github.com/celestiaorg/test-infra/tests/helpers/reconstruction"
 	"github.com/testground/sdk-go/run"
 	"github.com/testground/sdk-go/runtime"
 )
 
+var blockReconstruction = testkit.Roles{
+	"validator": reconstruction.RunAppValidator,
+	"bridge":    reconstruction.RunBridgeNode,
+	"full":      reconstruction.RunFullNode,
+	"light":     reconstruction.RunLightNode,
+}
+
 // BlockReconstruction represents all test-cases(1/2/3/4 Full Nodes) that
 // are trying to reconstruct the latest block from Light Nodes only
 // More information under docs/test-plans/004-Block-Reconstruction
-func BlockReconstruction(runenv *runtime.RunEnv, initCtx *run.InitContext) (err error) {
-	switch runenv.StringParam("role") {
-	case "validator":
-		err = reconstruction.RunAppValidator(runenv, initCtx)
-	case "bridge":
-		err = reconstruction.RunBridgeNode(runenv, initCtx)
-	case "full":
-		err = reconstruction.RunFullNode(runenv, initCtx)
-	case "light":
-		err = reconstruction.RunLightNode(runenv, initCtx)
-	}
-
-	if err != nil {
-		runenv.RecordFailure(err)
-		initCtx.SyncClient.MustSignalAndWait(context.Background(), testkit.FinishState, runenv.TestInstanceCount)
-		return err
-	}
-
-	runenv.RecordSuccess()
-	return nil
+func BlockReconstruction(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
+	return blockReconstruction.RunWithParams(runenv, initCtx, &reconstruction.Params{})
 }
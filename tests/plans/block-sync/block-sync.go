@@ -1,8 +1,6 @@
 package blocksync
 
 import (
-	"context"
-
 	"github.com/celestiaorg/test-infra/testkit"
 	blocksynchistorical "github.com/celestiaorg/test-infra/tests/helpers/block-sync/historical"
 	blocksynclatest "github.com/celestiaorg/test-infra/tests/helpers/block-sync/latest"
@@ -10,28 +8,25 @@ import (
 	"github.com/testground/sdk-go/runtime"
 )
 
+var blockSyncLatest = testkit.Roles{
+	"validator": blocksynclatest.RunValidator,
+	"bridge":    blocksynclatest.RunBridgeNode,
+	"full":      blocksynclatest.RunFullNode,
+}
+
 // BlockSyncLatest represents a testcase of W validator, X bridges, Y full nodes that
 // are trying to sync the latest block from Bridge Nodes and among themselves
 // using either ShrexGetter only, IPLDGetter only or the default CascadeGetter (_see compositions/cluster-k8s/blocksync-latest/*/*-{getter}.toml)
 // More information under docs/test-plans/005-Block-Sync
-func BlockSyncLatest(runenv *runtime.RunEnv, initCtx *run.InitContext) (err error) {
-	switch runenv.StringParam("role") {
-	case "validator":
-		err = blocksynclatest.RunValidator(runenv, initCtx)
-	case "bridge":
-		err = blocksynclatest.RunBridgeNode(runenv, initCtx)
-	case "full":
-		err = blocksynclatest.RunFullNode(runenv, initCtx)
-	}
-
-	if err != nil {
-		runenv.RecordFailure(err)
-		initCtx.SyncClient.MustSignalAndWait(context.Background(), testkit.FinishState, runenv.TestInstanceCount)
-		return err
-	}
+func BlockSyncLatest(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
+	return blockSyncLatest.RunWithParams(runenv, initCtx, &blocksynclatest.Params{})
+}
 
-	runenv.RecordSuccess()
-	return nil
+var blockSyncHistorical = testkit.Roles{
+	"validator":  blocksynchistorical.RunValidator,
+	"bridge":     blocksynchistorical.RunBridgeNode,
+	"full":       blocksynchistorical.RunFullNode,
+	"historical": blocksynchistorical.RunHistoricalFullNode,
 }
 
 // BlockSyncHistorical represents a testcase of W validator, X bridges, Y full nodes that
@@ -39,24 +34,6 @@ func BlockSyncLatest(runenv *runtime.RunEnv, initCtx *run.InitContext) (err erro
 // using either IPLD only or default getters (shrex with IPLD as fallback)
 // (_see compositions/cluster-k8s/block-sync/historical/*/*-{getter}.toml)
 // More information under docs/test-plans/005-Block-Sync
-func BlockSyncHistorical(runenv *runtime.RunEnv, initCtx *run.InitContext) (err error) {
-	switch runenv.StringParam("role") {
-	case "validator":
-		err = blocksynchistorical.RunValidator(runenv, initCtx)
-	case "bridge":
-		err = blocksynchistorical.RunBridgeNode(runenv, initCtx)
-	case "full":
-		err = blocksynchistorical.RunFullNode(runenv, initCtx)
-	case "historical":
-		err = blocksynchistorical.RunHistoricalFullNode(runenv, initCtx)
-	}
-
-	if err != nil {
-		runenv.RecordFailure(err)
-		initCtx.SyncClient.MustSignalAndWait(context.Background(), testkit.FinishState, runenv.TestInstanceCount)
-		return err
-	}
-
-	runenv.RecordSuccess()
-	return
+func BlockSyncHistorical(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
+	return blockSyncHistorical.RunWithParams(runenv, initCtx, &blocksynchistorical.Params{})
 }
@@ -0,0 +1,24 @@
+package byzantine
+
+import (
+	"github.com/celestiaorg/test-infra/testkit"
+	appsync "github.com/celestiaorg/test-infra/tests/helpers/app-sync"
+	"github.com/celestiaorg/test-infra/tests/helpers/byzantine"
+	nodesync "github.com/celestiaorg/test-infra/tests/helpers/node-sync"
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+)
+
+var byzantineBridge = testkit.Roles{
+	"seed":      appsync.RunSeed,
+	"validator": nodesync.RunAppValidator,
+	"bridge":    byzantine.RunBridgeNode,
+	"full":      byzantine.RunFullNode,
+	"light":     byzantine.RunLightNode,
+}
+
+// ByzantineBridge - Full and light nodes detect the attacks of byzantine bridges,
+// i.e. withheld or corrupted shares, see testkit/byzkit
+func ByzantineBridge(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
+	return byzantineBridge.RunWithParams(runenv, initCtx, &byzantine.Params{})
+}
@@ -0,0 +1,24 @@
+package dasbenchmarks
+
+import (
+	"github.com/celestiaorg/test-infra/testkit"
+	appsync "github.com/celestiaorg/test-infra/tests/helpers/app-sync"
+	"github.com/celestiaorg/test-infra/tests/helpers/byzantine"
+	daswithholding "github.com/celestiaorg/test-infra/tests/helpers/das-withholding"
+	nodesync "github.com/celestiaorg/test-infra/tests/helpers/node-sync"
+	"github.com/testground/sdk-go/run"
+	"github.com/testground/sdk-go/runtime"
+)
+
+var lightWithholding = testkit.Roles{
+	"seed":      appsync.RunSeed,
+	"validator": nodesync.RunAppValidator,
+	"bridge":    byzantine.RunBridgeNode,
+	"light":     daswithholding.RunLightNode,
+}
+
+// Test-Case #002 - Light nodes detect the withheld squares as often as their sample amount predicts
+// Description is in docs/test-plans/002-Das-Benchmarks/test-cases
+func LightWithholding(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
+	return lightWithholding.RunWithParams(runenv, initCtx, &daswithholding.Params{})
+}
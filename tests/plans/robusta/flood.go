@@ -1,27 +1,16 @@
 package robusta
 
 import (
-	"context"
-
 	"github.com/celestiaorg/test-infra/testkit"
 	"github.com/testground/sdk-go/run"
 	"github.com/testground/sdk-go/runtime"
 )
 
-func RunRobusta(runenv *runtime.RunEnv, initCtx *run.InitContext) (err error) {
-	switch runenv.StringParam("role") {
-	case "full":
-		err = RunFullNode(runenv, initCtx)
-	case "light":
-		err = RunLightNode(runenv, initCtx)
-	}
-
-	if err != nil {
-		runenv.RecordFailure(err)
-		initCtx.SyncClient.MustSignalAndWait(context.Background(), testkit.FinishState, runenv.TestInstanceCount)
-		return err
-	}
+var robustaRoles = testkit.Roles{
+	"full":  RunFullNode,
+	"light": RunLightNode,
+}
 
-	runenv.RecordSuccess()
-	return nil
+func RunRobusta(runenv *runtime.RunEnv, initCtx *run.InitContext) error {
+	return robustaRoles.RunWithParams(runenv, initCtx, &Params{})
 }
@@ -0,0 +1,23 @@
+package robusta
+
+import (
+	"errors"
+
+	"github.com/celestiaorg/test-infra/tests/helpers/common"
+)
+
+// Params of the robusta test-cases, where full and light nodes
+// join an existing network instead of spawning validators
+type Params struct {
+	common.Topology
+	common.Execution
+	common.DANode
+}
+
+func (p *Params) Validate() error {
+	return errors.Join(
+		p.Topology.Validate(),
+		p.Execution.Validate(),
+		p.DANode.Validate(),
+	)
+}
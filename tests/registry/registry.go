@@ -0,0 +1,47 @@
+/*
+Package registry is the single source of truth of the test cases implemented
+in this repository. Every test case declared in manifest.toml must be registered
+here and vice versa, which is checked by `make validate-manifest` and `go test ./cmd/validate-manifest`
+*/
+package registry
+
+import (
+	"github.com/celestiaorg/test-infra/tests/plans"
+	bigblocks "github.com/celestiaorg/test-infra/tests/plans/big-blocks"
+	blockrecon "github.com/celestiaorg/test-infra/tests/plans/block-recon"
+	blocksync "github.com/celestiaorg/test-infra/tests/plans/block-sync"
+	"github.com/celestiaorg/test-infra/tests/plans/byzantine"
+	dasbenchmarks "github.com/celestiaorg/test-infra/tests/plans/das-benchmarks"
+	pfdgsbn "github.com/celestiaorg/test-infra/tests/plans/pfd-gsbn"
+	"github.com/celestiaorg/test-infra/tests/plans/qgb"
+	"github.com/celestiaorg/test-infra/tests/plans/robusta"
+)
+
+// TestCases maps the name of every test case in manifest.toml to its implementation
+var TestCases = map[string]interface{}{
+	// Big Blocks Plan
+	"001-val-large-txs":   bigblocks.ValSubmitLargeTxs,
+	"002-da-sync":         bigblocks.SyncNodes,
+	"003-full-sync-past":  bigblocks.FullSyncPast,
+	"004-full-light-past": bigblocks.FullLightSyncPast,
+	"005-light-das-past":  bigblocks.LightDasPast,
+	// Pay For Blob & Get Shares by Namespace Plan
+	// PayForBlobAndGetShares is tracking TestCase key to know
+	// when to do shares checker scenario
+	"pay-for-blob":            pfdgsbn.PayForBlobAndGetShares,
+	"get-shares-by-namespace": pfdgsbn.PayForBlobAndGetShares,
+	// Block Reconstruction Plan
+	"reconstruction": blockrecon.BlockReconstruction,
+	// BlockSync Benchmarks - Syncing Latest
+	"blocksync-latest": blocksync.BlockSyncLatest,
+	// BlockSync Benchmarks - Syncing Historical
+	"blocksync-historical": blocksync.BlockSyncHistorical,
+	// Robusta Nightly Plan
+	"flood-robusta-nightly-1": robusta.RunRobusta,
+	"flood-internal":          plans.SyncNodes,
+	"qgb-test":                qgb.RunQGB,
+	// Byzantine Bridges Plan
+	"byzantine-bridge": byzantine.ByzantineBridge,
+	// DAS Benchmarks Plan
+	"das-withholding": dasbenchmarks.LightWithholding,
+}